@@ -0,0 +1,300 @@
+package hashi
+
+import (
+	"fmt"
+	"time"
+)
+
+type Board struct {
+	Grid       [][]*Island
+	Rows       int
+	Cols       int
+	AllIslands []*Island
+	AllRivers  []*River
+	Clusters   []*Cluster
+	//MaxBridges is the most bridges one river can hold; every format but
+	//Tatham's game IDs fixes it at 2, and CreateRiver treats 0 as 2
+	MaxBridges int
+	//when Explain is set, the deduction rules record each change in Moves
+	Explain bool
+	Moves   []Move
+	//Rules lists the deduction rules to run, in order; nil means DefaultRules()
+	Rules []Rule
+	stats Stats
+	//phaseStarts holds the start time of each phase that is being timed
+	phaseStarts map[string]time.Time
+	budget      *budget
+	forest      forest
+	trail       []undo
+	marks       int
+}
+
+// IsSolved reports whether b is a complete, valid solution. If it is not,
+// the error is the first of b.Violations().
+func (b *Board) IsSolved() (bool, error) {
+	var err error
+	b.eachViolation(func(v Violation) bool {
+		err = v
+		return false
+	})
+	return err == nil, err
+}
+
+// HasMistakes reports whether b's current state already rules out a
+// solution. If it does, the error is the first of b.Mistakes().
+func (b *Board) HasMistakes() (bool, error) {
+	var err error
+	b.eachMistake(func(v Violation) bool {
+		err = v
+		return false
+	})
+	return err != nil, err
+}
+
+func (b *Board) AddIsland(ct int, r int, c int) *Island {
+	i := Island{
+		Num:       ct,
+		Bridges:   0,
+		Available: 0,
+		R:         r,
+		C:         c,
+		Rivers:    []*River{},
+		board:     b,
+	}
+	cluster := b.addToForest(&i)
+	b.Grid[r][c] = &i
+	b.Clusters = append(b.Clusters, cluster)
+	b.AllIslands = append(b.AllIslands, &i)
+	return &i
+}
+
+// IslandAt returns the island at row r, column c, or nil if there is none
+// or the cell is off the board.
+func (b *Board) IslandAt(r int, c int) *Island {
+	if r < 0 || r >= b.Rows || c < 0 || c >= b.Cols {
+		return nil
+	}
+	return b.Grid[r][c]
+}
+
+func (b *Board) AddBridge(r *River) error {
+	if r.ToGive < 1 || r.Bridges >= r.Max {
+		return fmt.Errorf("river %s has no more bridges to give", r)
+	}
+	b.setInt(&r.Bridges, r.Bridges+1)
+	b.setToGive(r, r.ToGive-1)
+	r.Islands[0].Update()
+	r.Islands[1].Update()
+	b.joinClusters(r.Islands[0].Cluster(), r.Islands[1].Cluster())
+	for _, crossingRiver := range r.Crossings {
+		crossingRiver.SetToGive(0)
+	}
+	return nil
+}
+
+func (b *Board) AddBridgeBetween(ia *Island, ib *Island) error {
+	r := ia.RiverWith(ib)
+	if r == nil {
+		return fmt.Errorf("islands %s and %s are not adjacent", ia, ib)
+	}
+	return b.AddBridge(r)
+}
+
+func min3(a int, b int, c int) int {
+	return min(a, min(b, c))
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// generate a river between those two islands, properly initializing ToGive
+func (b *Board) CreateRiver(ia *Island, ib *Island) *River {
+	if b.MaxBridges == 0 {
+		b.MaxBridges = 2
+	}
+	r := River{
+		Islands:   []*Island{ia, ib},
+		Crossings: []*River{},
+		Bridges:   0,
+		ToGive:    min3(ia.Num, ib.Num, b.MaxBridges),
+		Max:       b.MaxBridges,
+		board:     b,
+	}
+	ia.addRiver(&r)
+	ib.addRiver(&r)
+	if r.ToGive > 0 {
+		b.riverLivenessChanged(&r, 1)
+	}
+	//fmt.Printf("New river: num %d num %d togive %d\n", ia.Num, ib.Num, r.ToGive)
+	b.AllRivers = append(b.AllRivers, &r)
+	ia.Update()
+	ib.Update()
+	return &r
+}
+
+// the direction here is arbitrary. we happened to add horizontal rivers first
+// in AddRivers, so we look for horizontal crossings here.
+func (b *Board) FindHorizontalRiverCrossing(r int, c int) *River {
+	var left *Island = nil
+	var right *Island = nil
+	for ci := c - 1; ci >= 0; ci-- {
+		if b.Grid[r][ci] != nil {
+			left = b.Grid[r][ci]
+			break
+		}
+	}
+	if left == nil {
+		return nil
+	}
+	for ci := c + 1; ci < b.Cols; ci++ {
+		if b.Grid[r][ci] != nil {
+			right = b.Grid[r][ci]
+			break
+		}
+	}
+	if right == nil {
+		return nil
+	}
+	return left.RiverWith(right)
+}
+
+func (b *Board) CreateRivers() {
+	//horizontal
+	for ri := 0; ri < b.Rows; ri++ {
+		var left *Island
+		for ci := 0; ci < b.Cols; ci++ {
+			right := b.Grid[ri][ci]
+			if right == nil {
+				continue
+			}
+
+			if left != nil {
+				b.CreateRiver(left, right)
+			}
+			left = right
+		}
+	}
+
+	//add verticals and track crossings
+	for ci := 0; ci < b.Cols; ci++ {
+		var top *Island
+		for ri := 0; ri < b.Rows; ri++ {
+			bottom := b.Grid[ri][ci]
+			if bottom == nil {
+				continue
+			}
+			if top != nil {
+				r := b.CreateRiver(top, bottom)
+				for crossRow := top.R + 1; crossRow < bottom.R; crossRow++ {
+					crossingRiver := b.FindHorizontalRiverCrossing(crossRow, ci)
+					if crossingRiver != nil {
+						markCrossing(crossingRiver, r)
+					}
+				}
+			}
+			top = bottom
+		}
+	}
+}
+
+func (b *Board) DebugOut() string {
+	out := ""
+	for _, i := range b.AllIslands {
+		out += fmt.Sprintf("%s\n", i)
+		for _, r := range i.Rivers {
+			out += fmt.Sprintf("\t%s\n", r)
+		}
+	}
+	return out
+}
+
+// emptyCopy returns a copy of b's puzzle with no bridges placed.
+func (b *Board) emptyCopy() *Board {
+	copy := Board{Grid: make([][]*Island, b.Rows), Rows: b.Rows, Cols: b.Cols, MaxBridges: b.MaxBridges, Clusters: []*Cluster{}, AllRivers: []*River{}, AllIslands: []*Island{}}
+	for ri := range copy.Grid {
+		copy.Grid[ri] = make([]*Island, copy.Cols)
+	}
+	for _, i := range b.AllIslands {
+		copy.AddIsland(i.Num, i.R, i.C)
+	}
+	copy.CreateRivers()
+	return &copy
+}
+
+func (b *Board) Clone() *Board {
+	b.startPhase("Clone board")
+	defer b.stopPhase("Clone board")
+	b.stats.Clones++
+	return b.clone()
+}
+
+// clone is Clone without counting or timing the copy on b, so that it only
+// reads b.
+func (b *Board) clone() *Board {
+	copy := Board{Grid: make([][]*Island, 0), Rows: b.Rows, Cols: b.Cols, MaxBridges: b.MaxBridges, Clusters: []*Cluster{}, AllRivers: []*River{}, AllIslands: []*Island{}}
+	for i := 0; i < copy.Rows; i++ {
+		copy.Grid = append(copy.Grid, make([]*Island, copy.Cols))
+	}
+	//clone islands and initialize clusters
+	for _, oldI := range b.AllIslands {
+		copy.AddIsland(oldI.Num, oldI.R, oldI.C)
+	}
+	//create rivers
+	for _, oldR := range b.AllRivers {
+		oldA := oldR.Islands[0]
+		oldB := oldR.Islands[1]
+		newA := copy.Grid[oldA.R][oldA.C]
+		newB := copy.Grid[oldB.R][oldB.C]
+		copy.CreateRiver(newA, newB)
+	}
+	//add crossings
+	for ci := 0; ci < copy.Cols; ci++ {
+		var top *Island
+		for ri := 0; ri < copy.Rows; ri++ {
+			bottom := copy.Grid[ri][ci]
+			if bottom == nil {
+				continue
+			}
+			if top != nil {
+				r := top.RiverWith(bottom)
+				for crossRow := top.R + 1; crossRow < bottom.R; crossRow++ {
+					crossingRiver := copy.FindHorizontalRiverCrossing(crossRow, ci)
+					if crossingRiver != nil {
+						markCrossing(crossingRiver, r)
+					}
+				}
+			}
+			top = bottom
+		}
+	}
+	//bridges and caps go on as they are, even ones the rules would refuse,
+	//so that a mistaken state stays mistaken; see BoardFromJSON
+	for idx, oldR := range b.AllRivers {
+		if oldR.Bridges > 0 {
+			copy.forceBridges(copy.AllRivers[idx], oldR.Bridges)
+		}
+	}
+	for idx, oldR := range b.AllRivers {
+		copy.AllRivers[idx].CapToGive(oldR.ToGive)
+	}
+	copy.Explain = b.Explain
+	copy.Rules = b.Rules
+	//the copy inherits the statistics, including its own creation
+	copy.stats = b.Stats()
+	for _, m := range b.Moves {
+		copy.Moves = append(copy.Moves, m.onBoard(&copy))
+	}
+
+	return &copy
+}
@@ -0,0 +1,83 @@
+package hashi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// listedBridge is one line of a bridge list.
+type listedBridge struct {
+	line   int
+	r1, c1 int
+	r2, c2 int
+	count  int
+}
+
+// parseBridgeList reads a bridge list: one river per line, written as the
+// row and column of each end followed by the number of bridges it carries.
+// The count defaults to 1, and blank lines and lines starting with # are
+// ignored.
+func parseBridgeList(data string) ([]listedBridge, error) {
+	bridges := []listedBridge{}
+	for ln, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 && len(fields) != 5 {
+			return nil, fmt.Errorf("line %d: expected r1 c1 r2 c2 [count], got %q", ln+1, line)
+		}
+		if len(fields) == 4 {
+			fields = append(fields, "1")
+		}
+		nums := make([]int, 5)
+		for idx, f := range fields {
+			n, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %q is not a number", ln+1, f)
+			}
+			nums[idx] = n
+		}
+		bridges = append(bridges, listedBridge{ln + 1, nums[0], nums[1], nums[2], nums[3], nums[4]})
+	}
+	return bridges, nil
+}
+
+// IsBridgeList reports whether data reads as a bridge list rather than a
+// drawn grid.
+func IsBridgeList(data string) bool {
+	bridges, err := parseBridgeList(data)
+	return err == nil && len(bridges) > 0
+}
+
+// ApplyBridges puts bridges on the board from a bridge list: one river per
+// line, written as the row and column of each end followed by the number of
+// bridges it should carry, as in "0 2 0 5 1". The count defaults to 1, and
+// blank lines and lines starting with # are ignored.
+func (b *Board) ApplyBridges(data string) error {
+	bridges, err := parseBridgeList(data)
+	if err != nil {
+		return err
+	}
+	for _, lb := range bridges {
+		ia, ib := b.IslandAt(lb.r1, lb.c1), b.IslandAt(lb.r2, lb.c2)
+		if ia == nil || ib == nil {
+			return fmt.Errorf("line %d: no island at both ends of the bridge", lb.line)
+		}
+		r := ia.RiverWith(ib)
+		if r == nil {
+			return fmt.Errorf("line %d: islands %s and %s are not adjacent", lb.line, ia, ib)
+		}
+		for r.Bridges < lb.count {
+			if err := b.AddBridge(r); err != nil {
+				return fmt.Errorf("line %d: %s", lb.line, err)
+			}
+		}
+		if r.Bridges > lb.count {
+			return fmt.Errorf("line %d: river %s already has %d bridges", lb.line, r, r.Bridges)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package hashi
+
+import "fmt"
+
+// Cluster is a view of one group of islands joined by bridges. It is
+// identified by an island index in the board's union-find forest and stays
+// meaningful after the group merges with another one, at which point it
+// describes the merged group.
+type Cluster struct {
+	board *Board
+	root  int
+}
+
+// forest tracks clusters as a union-find structure keyed by island index.
+// It never compresses paths, so every change is a handful of ints that the
+// board's undo trail can restore. Alongside the partition it keeps, for each
+// root, the islands' outstanding bridge needs and how many live rivers lead
+// out of the cluster.
+type forest struct {
+	parent []int
+	size   []int
+	//next links the members of each cluster into a ring
+	next []int
+	//need is the sum of NumNeeded() over a cluster's islands
+	need []int
+	//exits counts the live rivers with one end in the cluster
+	exits []int
+	//edges counts the cluster's islands with a nonzero liberty count
+	edges []int
+	//liberties counts an island's live rivers that leave its cluster
+	liberties []int
+	views     []*Cluster
+}
+
+func (f *forest) find(idx int) int {
+	for f.parent[idx] != idx {
+		idx = f.parent[idx]
+	}
+	return idx
+}
+
+func (b *Board) addToForest(i *Island) *Cluster {
+	f := &b.forest
+	idx := len(f.parent)
+	i.Index = idx
+	f.parent = append(f.parent, idx)
+	f.size = append(f.size, 1)
+	f.next = append(f.next, idx)
+	f.need = append(f.need, i.NumNeeded())
+	f.exits = append(f.exits, 0)
+	f.edges = append(f.edges, 0)
+	f.liberties = append(f.liberties, 0)
+	c := &Cluster{board: b, root: idx}
+	f.views = append(f.views, c)
+	return c
+}
+
+// changeExits adds delta to the number of live rivers leaving i's cluster
+// through i.
+func (b *Board) changeExits(i *Island, delta int) {
+	f := &b.forest
+	root := f.find(i.Index)
+	wasEdge := f.liberties[i.Index] > 0
+	b.setInt(&f.liberties[i.Index], f.liberties[i.Index]+delta)
+	b.setInt(&f.exits[root], f.exits[root]+delta)
+	if isEdge := f.liberties[i.Index] > 0; isEdge != wasEdge {
+		if isEdge {
+			b.setInt(&f.edges[root], f.edges[root]+1)
+		} else {
+			b.setInt(&f.edges[root], f.edges[root]-1)
+		}
+	}
+}
+
+// riverLivenessChanged updates the exit counts after r starts (delta 1) or
+// stops (delta -1) offering bridges.
+func (b *Board) riverLivenessChanged(r *River, delta int) {
+	ia, ib := r.Islands[0], r.Islands[1]
+	if b.forest.find(ia.Index) == b.forest.find(ib.Index) {
+		return
+	}
+	b.changeExits(ia, delta)
+	b.changeExits(ib, delta)
+}
+
+func (b *Board) setToGive(r *River, ct int) {
+	wasLive := r.ToGive > 0
+	b.setInt(&r.ToGive, ct)
+	if isLive := ct > 0; isLive != wasLive {
+		if isLive {
+			b.riverLivenessChanged(r, 1)
+		} else {
+			b.riverLivenessChanged(r, -1)
+		}
+	}
+}
+
+func (b *Board) setBridges(i *Island, ct int) {
+	root := b.forest.find(i.Index)
+	b.setInt(&b.forest.need[root], b.forest.need[root]-(ct-i.Bridges))
+	b.setInt(&i.Bridges, ct)
+}
+
+// RemoveCluster drops deadcluster from b.Clusters, moving the last cluster
+// into its slot, and returns the slot it occupied (or -1 if it was not found).
+func (b *Board) RemoveCluster(deadcluster *Cluster) int {
+	for i, c := range b.Clusters {
+		if c == deadcluster {
+			b.Clusters[i] = b.Clusters[len(b.Clusters)-1]
+			b.Clusters = b.Clusters[:len(b.Clusters)-1]
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *Board) joinClusters(ca *Cluster, cb *Cluster) bool {
+	f := &b.forest
+	ra, rb := f.find(ca.root), f.find(cb.root)
+	if ra == rb {
+		return false
+	}
+	if f.size[ra] < f.size[rb] {
+		ra, rb = rb, ra
+	}
+	//live rivers between the two clusters no longer lead out of either
+	for x := rb; ; {
+		i := b.AllIslands[x]
+		for _, r := range i.Rivers {
+			if r.ToGive > 0 && f.find(r.Neighbor(i).Index) == ra {
+				b.changeExits(i, -1)
+				b.changeExits(r.Neighbor(i), -1)
+			}
+		}
+		x = f.next[x]
+		if x == rb {
+			break
+		}
+	}
+	b.setInt(&f.parent[rb], ra)
+	b.setInt(&f.size[ra], f.size[ra]+f.size[rb])
+	b.setInt(&f.need[ra], f.need[ra]+f.need[rb])
+	b.setInt(&f.exits[ra], f.exits[ra]+f.exits[rb])
+	b.setInt(&f.edges[ra], f.edges[ra]+f.edges[rb])
+	//swapping successors splices the two rings into one
+	na, nb := f.next[ra], f.next[rb]
+	b.setInt(&f.next[ra], nb)
+	b.setInt(&f.next[rb], na)
+	slot := b.RemoveCluster(f.views[rb])
+	b.record(undo{kind: undoJoin, cluster: f.views[rb], old: slot})
+	return true
+}
+
+// splitClusters puts cb back in the slot of b.Clusters that joinClusters
+// removed it from. The forest itself is restored by the int changes recorded
+// alongside the join.
+func (b *Board) splitClusters(cb *Cluster, slot int) {
+	if slot == len(b.Clusters) {
+		b.Clusters = append(b.Clusters, cb)
+		return
+	}
+	b.Clusters = append(b.Clusters, b.Clusters[slot])
+	b.Clusters[slot] = cb
+}
+
+func (c *Cluster) rootIndex() int {
+	return c.board.forest.find(c.root)
+}
+
+// Islands returns the islands in the cluster.
+func (c *Cluster) Islands() []*Island {
+	f := &c.board.forest
+	root := c.rootIndex()
+	ret := make([]*Island, 0, f.size[root])
+	for x := root; ; {
+		ret = append(ret, c.board.AllIslands[x])
+		x = f.next[x]
+		if x == root {
+			break
+		}
+	}
+	return ret
+}
+
+func (c *Cluster) Contains(i *Island) bool {
+	return c.board.forest.find(i.Index) == c.rootIndex()
+}
+
+func (c *Cluster) Size() int {
+	return c.board.forest.size[c.rootIndex()]
+}
+
+// Need returns the number of bridge ends the cluster's islands still need.
+func (c *Cluster) Need() int {
+	return c.board.forest.need[c.rootIndex()]
+}
+
+// NumExits returns the number of live rivers leading out of the cluster.
+func (c *Cluster) NumExits() int {
+	return c.board.forest.exits[c.rootIndex()]
+}
+
+// NumEdges returns the number of islands in the cluster that have a live
+// river leading out of it; it is len(c.Edges()) without the scan.
+func (c *Cluster) NumEdges() int {
+	return c.board.forest.edges[c.rootIndex()]
+}
+
+func (c *Cluster) IncompleteIslands() []*Island {
+	ret := []*Island{}
+	for _, i := range c.Islands() {
+		if !i.IsComplete() {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+func (c *Cluster) Edges() []*Island {
+	edges := []*Island{}
+	if c.NumEdges() == 0 {
+		return edges
+	}
+	for _, i := range c.Islands() {
+		if c.board.forest.liberties[i.Index] > 0 {
+			edges = append(edges, i)
+		}
+	}
+	return edges
+}
+
+func (c *Cluster) String() string {
+	out := fmt.Sprintf("Cluster of size %d: ", c.Size())
+	for _, i := range c.Islands() {
+		out += fmt.Sprintf("%s ", i)
+	}
+	out += fmt.Sprintf("Edges: %v", c.Edges())
+	return out
+}
@@ -0,0 +1,131 @@
+package hashi
+
+import "testing"
+
+// checkClusters recomputes b's clusters from scratch with a plain union-find
+// over the rivers that have bridges and compares them, and their counts,
+// with the ones b keeps incrementally.
+func checkClusters(t *testing.T, b *Board, where string) {
+	t.Helper()
+	parent := make([]int, len(b.AllIslands))
+	for idx := range parent {
+		parent[idx] = idx
+	}
+	var find func(int) int
+	find = func(idx int) int {
+		if parent[idx] != idx {
+			parent[idx] = find(parent[idx])
+		}
+		return parent[idx]
+	}
+	for _, r := range b.AllRivers {
+		if r.Bridges > 0 {
+			parent[find(r.Islands[0].Index)] = find(r.Islands[1].Index)
+		}
+	}
+	size := make(map[int]int)
+	need := make(map[int]int)
+	exits := make(map[int]int)
+	edges := make(map[int]int)
+	for _, i := range b.AllIslands {
+		root := find(i.Index)
+		size[root]++
+		need[root] += i.NumNeeded()
+		liberties := 0
+		for _, r := range i.LiveRivers {
+			if find(r.Neighbor(i).Index) != root {
+				liberties++
+			}
+		}
+		exits[root] += liberties
+		if liberties > 0 {
+			edges[root]++
+		}
+	}
+	if len(b.Clusters) != len(size) {
+		t.Fatalf("%s: %d clusters, want %d", where, len(b.Clusters), len(size))
+	}
+	seen := make(map[int]bool)
+	for _, c := range b.Clusters {
+		islands := c.Islands()
+		root := find(islands[0].Index)
+		if seen[root] {
+			t.Fatalf("%s: two clusters hold %s", where, islands[0])
+		}
+		seen[root] = true
+		for _, i := range islands {
+			if find(i.Index) != root || i.Cluster() != c || !c.Contains(i) {
+				t.Fatalf("%s: %s is in the wrong cluster", where, i)
+			}
+		}
+		if len(islands) != size[root] || c.Size() != size[root] {
+			t.Fatalf("%s: cluster of %s has %d islands (Size %d), want %d", where, islands[0], len(islands), c.Size(), size[root])
+		}
+		if c.Need() != need[root] || c.NumExits() != exits[root] || c.NumEdges() != edges[root] || len(c.Edges()) != edges[root] {
+			t.Fatalf("%s: cluster of %s has need %d, exits %d, edges %d; want %d, %d, %d",
+				where, islands[0], c.Need(), c.NumExits(), c.NumEdges(), need[root], exits[root], edges[root])
+		}
+	}
+}
+
+// square returns a board of four 2s at the corners of a 3x3 grid and its
+// top, bottom and left rivers.
+func square(t *testing.T) (b *Board, top *River, bottom *River, left *River) {
+	b = mustParse(t, "2.2\n...\n2.2\n")
+	return b, b.Grid[0][0].RiverWith(b.Grid[0][2]), b.Grid[2][0].RiverWith(b.Grid[2][2]), b.Grid[0][0].RiverWith(b.Grid[2][0])
+}
+
+func TestClusterJoinCounts(t *testing.T) {
+	b, top, bottom, left := square(t)
+	checkClusters(t, b, "empty board")
+	b.AddBridge(top)
+	checkClusters(t, b, "top bridge")
+	b.AddBridge(bottom)
+	checkClusters(t, b, "bottom bridge")
+	//joins two clusters of two islands each
+	cp := b.Checkpoint()
+	b.AddBridge(left)
+	checkClusters(t, b, "left bridge")
+	c := b.Grid[0][0].Cluster()
+	if len(b.Clusters) != 1 || c.Need() != 2 || c.NumExits() != 0 || c.NumEdges() != 0 {
+		t.Fatalf("after joining: %d clusters, need %d, exits %d, edges %d; want 1, 2, 0, 0", len(b.Clusters), c.Need(), c.NumExits(), c.NumEdges())
+	}
+	b.Rollback(cp)
+	checkClusters(t, b, "rollback of the join")
+	if len(b.Clusters) != 2 {
+		t.Errorf("%d clusters after rollback, want 2", len(b.Clusters))
+	}
+}
+
+func TestClusterCapCounts(t *testing.T) {
+	b, top, _, left := square(t)
+	b.AddBridge(top)
+	c := b.Grid[0][0].Cluster()
+	exits := c.NumExits()
+	//a river inside the cluster does not lead out of it
+	top.SetToGive(0)
+	checkClusters(t, b, "cap inside the cluster")
+	if c.NumExits() != exits {
+		t.Errorf("capping an inner river changed exits from %d to %d", exits, c.NumExits())
+	}
+	left.SetToGive(0)
+	checkClusters(t, b, "cap between clusters")
+	if c.NumExits() != exits-1 {
+		t.Errorf("capping an outer river left exits at %d, want %d", c.NumExits(), exits-1)
+	}
+}
+
+func TestSolvedBoardIsOneCluster(t *testing.T) {
+	b, err := ParseFile("problem36.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := Solve(b)
+	if !res.Solved {
+		t.Fatalf("not solved: %v", res.Reason)
+	}
+	checkClusters(t, res.Board, "solved board")
+	if len(res.Board.Clusters) != 1 {
+		t.Errorf("%d clusters, want 1", len(res.Board.Clusters))
+	}
+}
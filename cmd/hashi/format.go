@@ -0,0 +1,156 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+// puzzleFlags are the flags that say how to read a command's puzzles.
+type puzzleFlags struct {
+	format *string
+	entry  *string
+	strict *bool
+}
+
+func addPuzzleFlags(fs *flag.FlagSet) *puzzleFlags {
+	return &puzzleFlags{
+		format: fs.String("format", "text", "puzzle format: text (a file with a text grid, JSON or a collection), tatham (a game ID) or puzzlink (a URL)"),
+		entry:  fs.String("entry", "", "the ID or number of the collection entry to use"),
+		strict: fs.Bool("strict", false, "reject text grids with stray characters, ragged rows or blank lines"),
+	}
+}
+
+// puzzle is one puzzle read from the command line, along with its
+// collection entry if it came from one and the time it took to load, for
+// the "Load board" phase of -t.
+type puzzle struct {
+	name  string
+	entry *hashi.Entry
+	board *hashi.Board
+	load  time.Duration
+}
+
+// loadBoard reads the one puzzle named by args.
+func (pf *puzzleFlags) loadBoard(args []string) (*hashi.Board, error) {
+	p, err := pf.loadPuzzle(args)
+	if err != nil {
+		return nil, err
+	}
+	return p.board, nil
+}
+
+// loadPuzzle is loadBoard for commands that also want the load time.
+func (pf *puzzleFlags) loadPuzzle(args []string) (puzzle, error) {
+	if len(args) != 1 {
+		printUsage()
+		return puzzle{}, fmt.Errorf("expected one problem file, got %d arguments", len(args))
+	}
+	puzzles, err := pf.readPuzzles(args[0])
+	if err != nil {
+		return puzzle{}, err
+	}
+	if len(puzzles) > 1 {
+		return puzzle{}, fmt.Errorf("%s holds %d puzzles; pick one with -entry", args[0], len(puzzles))
+	}
+	return puzzles[0], nil
+}
+
+// readPuzzles reads the puzzles named by arg, which is a file name for the
+// text format and the puzzle itself for formats that fit on the command
+// line. A collection file yields each of its entries, or just the one
+// picked by -entry.
+func (pf *puzzleFlags) readPuzzles(arg string) ([]puzzle, error) {
+	start := time.Now()
+	var b *hashi.Board
+	var err error
+	switch *pf.format {
+	case "text":
+		return pf.readCollection(arg)
+	case "tatham":
+		b, err = hashi.BoardFromTatham(arg)
+		if err != nil {
+			return nil, fmt.Errorf("error reading game ID: %s", err)
+		}
+	case "puzzlink":
+		b, err = hashi.BoardFromPuzzLink(arg)
+		if err != nil {
+			return nil, fmt.Errorf("error reading URL: %s", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q", *pf.format)
+	}
+	if *pf.entry != "" {
+		return nil, fmt.Errorf("-entry only applies to collection files")
+	}
+	return []puzzle{{name: arg, board: b, load: time.Since(start)}}, nil
+}
+
+// readCollection reads the entries of a collection file. Reading the file
+// counts toward the first puzzle's load time, and parsing each entry
+// toward its own.
+func (pf *puzzleFlags) readCollection(fn string) ([]puzzle, error) {
+	start := time.Now()
+	entries, err := hashi.ReadCollection(fn)
+	if err != nil {
+		return nil, fmt.Errorf("error loading file: %s", err)
+	}
+	if *pf.entry != "" {
+		e, err := hashi.SelectEntry(entries, *pf.entry)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", fn, err)
+		}
+		entries = []*hashi.Entry{e}
+	}
+	puzzles := []puzzle{}
+	for _, e := range entries {
+		load := e.Board
+		if *pf.strict {
+			load = e.BoardStrict
+		}
+		b, err := load()
+		if err != nil {
+			return nil, fmt.Errorf("error loading file: %s", err)
+		}
+		puzzles = append(puzzles, puzzle{name: e.Name(), entry: e, board: b, load: time.Since(start)})
+		start = time.Now()
+	}
+	return puzzles, nil
+}
+
+// writePuzzle renders b's clues in the given format, which may also be
+// json.
+func writePuzzle(format string, b *hashi.Board) (string, error) {
+	switch format {
+	case "text":
+		return b.PuzzleString(), nil
+	case "json":
+		data, err := b.PuzzleJSON()
+		return string(data), err
+	case "tatham":
+		return b.TathamID(), nil
+	case "puzzlink":
+		return b.PuzzLinkURL()
+	}
+	return "", fmt.Errorf("unknown format %q", format)
+}
+
+// applyState puts the bridges from the named file, a bridge list or a drawn
+// grid, onto b.
+func applyState(b *hashi.Board, fn string) error {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return fmt.Errorf("error loading state: %s", err)
+	}
+	apply := b.ApplySolution
+	if hashi.IsBridgeList(string(data)) {
+		apply = b.ApplyBridges
+	}
+	if err := apply(string(data)); err != nil {
+		return fmt.Errorf("error applying state: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+func runGen(args []string) error {
+	fs := newFlagSet("gen")
+	opts := hashi.GenOptions{}
+	fs.IntVar(&opts.Rows, "rows", 7, "number of rows")
+	fs.IntVar(&opts.Cols, "cols", 7, "number of columns")
+	fs.Float64Var(&opts.Density, "density", 0.25, "fraction of cells that hold islands")
+	fs.Int64Var(&opts.Seed, "seed", 0, "random seed (0 picks one from the clock)")
+	fs.IntVar(&opts.MinLevel, "level", 0, "keep only puzzles that rate at this level or higher (see rate)")
+	fs.IntVar(&opts.MaxAttempts, "attempts", 0, "give up after trying this many layouts (default 1000)")
+	out := fs.String("o", "", "write the puzzle to this file instead of stdout")
+	format := fs.String("format", "text", "output format: text, json, tatham or puzzlink")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		printUsage()
+		return fmt.Errorf("gen takes no problem file")
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	b, err := hashi.Generate(opts)
+	if err != nil {
+		return err
+	}
+	puzzle, err := writePuzzle(*format, b)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "seed: %d\n", opts.Seed)
+	if *out == "" {
+		fmt.Printf("%s\n", puzzle)
+		return nil
+	}
+	return os.WriteFile(*out, []byte(puzzle+"\n"), 0644)
+}
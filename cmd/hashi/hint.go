@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+)
+
+func runHint(args []string) error {
+	fs := newFlagSet("hint")
+	state := fs.String("state", "", "bridge list or drawn grid of the bridges already placed")
+	rules := fs.String("rules", "", "comma-separated deduction rules to try, in order")
+	styles := addStyleFlags(fs)
+	puzzles := addPuzzleFlags(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	draw, err := styles.drawer()
+	if err != nil {
+		return err
+	}
+	b, err := puzzles.loadBoard(args)
+	if err != nil {
+		return err
+	}
+	if err := setRules(b, *rules); err != nil {
+		return err
+	}
+	if *state != "" {
+		if err := applyState(b, *state); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%s\n", draw(b))
+	hint, err := b.NextHint()
+	if err != nil {
+		return fmt.Errorf("the board already has a mistake: %s", err)
+	}
+	if hint == nil {
+		fmt.Printf("No hint: the deduction rules are stuck\n")
+		return nil
+	}
+	fmt.Printf("Hint: %s\n", hint.Action())
+	fmt.Printf("Rule: %s\n", hint.Rule)
+	fmt.Printf("Why: %s\n", hint.Reason)
+	fmt.Printf("Look at:")
+	for _, i := range hint.Islands {
+		fmt.Printf(" (r%d, c%d)", i.R, i.C)
+	}
+	fmt.Printf("\n")
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+// limitFlags are the flags that cap the work a command does on a puzzle.
+type limitFlags struct {
+	timeout *time.Duration
+	limits  hashi.Limits
+}
+
+func addLimitFlags(fs *flag.FlagSet) *limitFlags {
+	lf := &limitFlags{timeout: fs.Duration("timeout", 0, "give up after this long (e.g. 10s)")}
+	fs.IntVar(&lf.limits.MaxNodes, "nodes", 0, "give up after visiting this many search states")
+	fs.IntVar(&lf.limits.MaxProbes, "probes", 0, "give up after this many MakeAGuess probes")
+	return lf
+}
+
+// context returns a context that is done once the timeout has passed, if
+// there is one.
+func (lf *limitFlags) context() (context.Context, context.CancelFunc) {
+	if *lf.timeout > 0 {
+		return context.WithTimeout(context.Background(), *lf.timeout)
+	}
+	return context.WithCancel(context.Background())
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+func runLint(args []string) error {
+	fs := newFlagSet("lint")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		printUsage()
+		return fmt.Errorf("expected at least one problem file")
+	}
+	problems := 0
+	for _, fn := range args {
+		entries, err := hashi.ReadCollection(fn)
+		if err != nil {
+			fmt.Printf("%s: %s\n", fn, err)
+			problems++
+			continue
+		}
+		for _, e := range entries {
+			for _, d := range e.Lint() {
+				fmt.Printf("%s:%d:%d: %s\n", fn, d.Line, d.Col, d.Message)
+				problems++
+			}
+			if _, err := e.Board(); err != nil {
+				fmt.Printf("%s:%d: %s\n", fn, e.PuzzleLine, err)
+				problems++
+			}
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("%d problems found", problems)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+func runList(args []string) error {
+	fs := newFlagSet("list")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		printUsage()
+		return fmt.Errorf("expected at least one collection file")
+	}
+	for _, fn := range args {
+		entries, err := hashi.ReadCollection(fn)
+		if err != nil {
+			return fmt.Errorf("error loading file: %s", err)
+		}
+		for _, e := range entries {
+			size := "?"
+			if b, err := e.Board(); err == nil {
+				size = fmt.Sprintf("%dx%d", b.Rows, b.Cols)
+			}
+			fmt.Printf("%s\t%d\t%s\t%s", fn, e.Index, e.Name(), size)
+			for _, field := range []string{e.Title, e.Author, e.Difficulty, e.Source} {
+				if field != "" {
+					fmt.Printf("\t%s", field)
+				}
+			}
+			if e.Solution != "" {
+				fmt.Printf("\t(has solution)")
+			}
+			fmt.Print("\n")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func lookupCommand(name string) func(args []string) error {
+	switch name {
+	case "solve":
+		return runSolve
+	case "uniq":
+		return runUniq
+	case "hint":
+		return runHint
+	case "gen":
+		return runGen
+	case "rate":
+		return runRate
+	case "verify":
+		return runVerify
+	case "list":
+		return runList
+	case "lint":
+		return runLint
+	case "render":
+		return runRender
+	case "replay":
+		return runReplay
+	}
+	return nil
+}
+
+func printUsage() {
+	fmt.Printf("usage: %s [command] [problemfile] [options]\n", os.Args[0])
+	fmt.Printf("commands:\tsolve (default): solve the puzzle\n")
+	fmt.Printf("\t\tuniq: count the puzzle's solutions\n")
+	fmt.Printf("\t\thint: show the next logical move\n")
+	fmt.Printf("\t\tgen: generate a uniquely solvable puzzle (no problemfile)\n")
+	fmt.Printf("\t\trate: rate the difficulty of one or more puzzles\n")
+	fmt.Printf("\t\tverify: check a solution file against the puzzle (problemfile solutionfile)\n")
+	fmt.Printf("\t\tlist: list the puzzles in one or more collection files\n")
+	fmt.Printf("\t\trender: draw the puzzle as an SVG (-svg) or PNG (-png) image\n")
+	fmt.Printf("\t\treplay: solve the puzzle and write an animated GIF of every move\n")
+	fmt.Printf("\t\tlint: report stray characters, ragged rows and blank lines in problem files\n")
+	fmt.Printf("options:\t-t: print execution time profile\n")
+	fmt.Printf("\t\t-explain: (solve) print the reason for every move\n")
+	fmt.Printf("\t\t-stats: (solve) print moves per rule, probes, search depth and phase times\n")
+	fmt.Printf("\t\t-timeout d, -nodes n, -probes n: (solve) give up after d (e.g. 10s), n search states or n probes\n")
+	fmt.Printf("\t\t-limit n: (uniq) stop counting after n solutions\n")
+	fmt.Printf("\t\t-state file: (hint, render) bridge list or drawn grid of the bridges placed so far\n")
+	fmt.Printf("\t\t-mode m, -cell n, -o file: (render) draw the puzzle, solution, state or mistakes, n pixels per cell, to a file\n")
+	fmt.Printf("\t\t-cell n, -delay n, -o file: (replay) n pixels per cell, frame time in 1/100 s, output file\n")
+	fmt.Printf("\t\t-rules a,b,...: (solve, hint, render, replay) deduction rules to run, in order\n")
+	fmt.Printf("\t\t-format f: read the puzzle as text (a grid, JSON or collection file), tatham (a game ID) or puzzlink (a URL); gen also writes json\n")
+	fmt.Printf("\t\t-style s: (solve, uniq, hint) draw boards as ascii or unicode (box-drawing characters)\n")
+	fmt.Printf("\t\t-color c: (solve, uniq, hint) color boards: auto (only on a terminal), always or never\n")
+	fmt.Printf("\t\t-strict: refuse problem files that lint finds fault with\n")
+	fmt.Printf("\t\t-entry id: use the collection entry with this ID or number; solve does each in turn without it\n")
+	fmt.Printf("\t\t-rows n, -cols n, -density f, -seed n, -o file: (gen) size, island density, seed and output\n")
+}
+
+// parseArgs parses fs's flags out of args, allowing them to come before,
+// after or between the positional arguments, which it returns.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = printUsage
+	return fs
+}
+
+func main() {
+	args := os.Args[1:]
+	run := runSolve
+	if len(args) > 0 {
+		if cmd := lookupCommand(args[0]); cmd != nil {
+			run = cmd
+			args = args[1:]
+		}
+	}
+	if err := run(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Printf("%s\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+//todo: precompute small clusters? 3: 2 1 1?
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+func runRate(args []string) error {
+	fs := newFlagSet("rate")
+	puzzles := addPuzzleFlags(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		printUsage()
+		return fmt.Errorf("expected at least one problem file")
+	}
+	for _, arg := range args {
+		ps, err := puzzles.readPuzzles(arg)
+		if err != nil {
+			return err
+		}
+		for _, p := range ps {
+			rating, err := hashi.Rate(p.board)
+			if err != nil {
+				return err
+			}
+			label := arg
+			if p.entry != nil && len(ps) > 1 {
+				label = fmt.Sprintf("%s %s", arg, p.name)
+			}
+			fmt.Printf("%s: %s\n", label, rating)
+		}
+	}
+	return nil
+}
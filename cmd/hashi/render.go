@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+func runRender(args []string) error {
+	fs := newFlagSet("render")
+	svg := fs.Bool("svg", false, "draw an SVG image (the default)")
+	asPNG := fs.Bool("png", false, "draw a PNG image")
+	mode := fs.String("mode", "solution", "what to draw: puzzle, solution, state or mistakes")
+	state := fs.String("state", "", "(state and mistakes modes) bridge list or drawn grid of the bridges placed so far")
+	rules := fs.String("rules", "", "(state mode) comma-separated deduction rules to run, in order")
+	cellSize := fs.Int("cell", 40, "size of a grid cell in pixels")
+	out := fs.String("o", "", "write the image to this file instead of stdout")
+	puzzles := addPuzzleFlags(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *svg && *asPNG {
+		return fmt.Errorf("pick one of -svg and -png")
+	}
+	drawMode, err := hashi.ParseDrawMode(*mode)
+	if err != nil {
+		return err
+	}
+	b, err := puzzles.loadBoard(args)
+	if err != nil {
+		return err
+	}
+	if err := setRules(b, *rules); err != nil {
+		return err
+	}
+	b, err = boardToDraw(b, drawMode, *state)
+	if err != nil {
+		return err
+	}
+	var img []byte
+	if *asPNG {
+		buf := bytes.Buffer{}
+		if err := b.PNG(&buf, hashi.PNGOptions{Mode: drawMode, CellSize: *cellSize}); err != nil {
+			return err
+		}
+		img = buf.Bytes()
+	} else {
+		img = []byte(b.SVG(hashi.SVGOptions{Mode: drawMode, CellSize: *cellSize}))
+	}
+	if *out == "" {
+		_, err := os.Stdout.Write(img)
+		return err
+	}
+	return os.WriteFile(*out, img, 0644)
+}
+
+// boardToDraw returns the board a picture in the given mode shows: the
+// solution in solution mode, the bridges from the state file in mistakes
+// mode, and in state mode the bridges from the state file or, without one,
+// as far as the deduction rules get.
+func boardToDraw(b *hashi.Board, mode hashi.DrawMode, state string) (*hashi.Board, error) {
+	switch {
+	case mode == hashi.DrawSolution:
+		res := hashi.Solve(b)
+		if !res.Solved {
+			return nil, fmt.Errorf("cannot draw the solution: %s", res.Reason)
+		}
+		return res.Board, nil
+	case (mode == hashi.DrawState || mode == hashi.DrawMistakes) && state != "":
+		return b, applyState(b, state)
+	case mode == hashi.DrawState:
+		b.AutoSolve(false)
+	}
+	return b, nil
+}
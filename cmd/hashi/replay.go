@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+func runReplay(args []string) error {
+	fs := newFlagSet("replay")
+	rules := fs.String("rules", "", "comma-separated deduction rules to run, in order")
+	cellSize := fs.Int("cell", 40, "size of a grid cell in pixels")
+	delay := fs.Int("delay", 50, "how long each frame shows, in hundredths of a second")
+	out := fs.String("o", "", "write the GIF to this file instead of stdout")
+	puzzles := addPuzzleFlags(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	b, err := puzzles.loadBoard(args)
+	if err != nil {
+		return err
+	}
+	if err := setRules(b, *rules); err != nil {
+		return err
+	}
+	b.Explain = true
+	res := hashi.Solve(b)
+	if !res.Solved {
+		fmt.Fprintf(os.Stderr, "not solved (%v); replaying the moves made\n", res.Reason)
+	}
+	buf := bytes.Buffer{}
+	if err := res.Board.ReplayGIF(&buf, hashi.GIFOptions{CellSize: *cellSize, Delay: *delay}); err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(*out, buf.Bytes(), 0644)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+// setRules configures b to run the comma-separated list of rule names, if
+// there is one.
+func setRules(b *hashi.Board, names string) error {
+	if names == "" {
+		return nil
+	}
+	rules, err := hashi.RulesByName(strings.Split(names, ","))
+	if err != nil {
+		return err
+	}
+	b.Rules = rules
+	return nil
+}
+
+// printProfile prints the time spent in each phase, and in total since
+// start, for the -t flag.
+func printProfile(phases map[string]time.Duration, start time.Time) {
+	names := []string{}
+	for name := range phases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%s: %.4f\n", name, phases[name].Seconds())
+	}
+	fmt.Printf("TOTAL: %.4f\n", time.Since(start).Seconds())
+}
+
+// solveOptions are runSolve's flags that apply to each puzzle.
+type solveOptions struct {
+	rules   string
+	explain bool
+	stats   bool
+	asJSON  bool
+	draw    func(b *hashi.Board) string
+	limit   *limitFlags
+	//phases adds up the time each solve spent in each phase
+	phases map[string]time.Duration
+}
+
+func runSolve(args []string) error {
+	start := time.Now()
+	fs := newFlagSet("solve")
+	opts := solveOptions{phases: map[string]time.Duration{}}
+	timer := fs.Bool("t", false, "print execution time profile")
+	fs.BoolVar(&opts.explain, "explain", false, "print the reason for every move")
+	fs.StringVar(&opts.rules, "rules", "", "comma-separated deduction rules to run, in order")
+	fs.BoolVar(&opts.stats, "stats", false, "print solver statistics")
+	fs.BoolVar(&opts.asJSON, "json", false, "print the final board as JSON")
+	opts.limit = addLimitFlags(fs)
+	styles := addStyleFlags(fs)
+	puzzles := addPuzzleFlags(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if opts.draw, err = styles.drawer(); err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		printUsage()
+		return fmt.Errorf("expected one problem file, got %d arguments", len(args))
+	}
+	ps, err := puzzles.readPuzzles(args[0])
+	if err != nil {
+		return err
+	}
+	for idx, p := range ps {
+		if len(ps) > 1 {
+			if idx > 0 {
+				fmt.Print("\n")
+			}
+			fmt.Printf("== %s", p.name)
+			if p.entry.Title != "" {
+				fmt.Printf(": %s", p.entry.Title)
+			}
+			fmt.Print("\n")
+		}
+		if err := solveOne(p, opts); err != nil {
+			return err
+		}
+	}
+	if *timer {
+		printProfile(opts.phases, start)
+	}
+	return nil
+}
+
+func solveOne(p puzzle, opts solveOptions) error {
+	b := p.board
+	if err := setRules(b, opts.rules); err != nil {
+		return err
+	}
+	b.Explain = opts.explain
+	ctx, cancel := opts.limit.context()
+	defer cancel()
+	res := hashi.SolveContext(ctx, b, opts.limit.limits)
+	opts.phases["Load board"] += p.load
+	for phase, d := range res.Stats.Phases {
+		opts.phases[phase] += d
+	}
+	if opts.explain {
+		for idx, m := range res.Board.Moves {
+			fmt.Printf("%d. %s\n", idx+1, m)
+		}
+	}
+	if opts.asJSON {
+		data, err := res.Board.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", data)
+	} else {
+		fmt.Printf("%s\n", opts.draw(res.Board))
+	}
+	fmt.Printf("Solved: %v", res.Solved)
+	if res.Reason != nil {
+		fmt.Printf(" (%v)", res.Reason)
+	}
+	fmt.Print("\n")
+	if p.entry != nil && p.entry.Solution != "" && res.Solved {
+		match, err := p.entry.Matches(res.Board)
+		if err != nil {
+			return err
+		}
+		if match {
+			fmt.Printf("Matches the expected solution\n")
+		} else {
+			fmt.Printf("Differs from the expected solution\n")
+		}
+	}
+	if opts.stats {
+		fmt.Printf("%s\n", res.Stats)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+// styleFlags are the flags that say how to draw boards.
+type styleFlags struct {
+	style *string
+	color *string
+}
+
+func addStyleFlags(fs *flag.FlagSet) *styleFlags {
+	return &styleFlags{
+		style: fs.String("style", "ascii", "how to draw boards: ascii or unicode"),
+		color: fs.String("color", "auto", "color boards by cluster and progress: auto (when stdout is a terminal), always or never"),
+	}
+}
+
+// drawer returns the function that draws boards the way the flags ask.
+func (sf *styleFlags) drawer() (func(b *hashi.Board) string, error) {
+	if *sf.style != "ascii" && *sf.style != "unicode" {
+		return nil, fmt.Errorf("unknown style %q", *sf.style)
+	}
+	unicode := *sf.style == "unicode"
+	color := false
+	switch *sf.color {
+	case "auto":
+		color = stdoutIsTerminal() && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+	case "always":
+		color = true
+	case "never":
+	default:
+		return nil, fmt.Errorf("unknown color setting %q", *sf.color)
+	}
+	switch {
+	case color:
+		return func(b *hashi.Board) string {
+			return b.ColorString(unicode)
+		}, nil
+	case unicode:
+		return (*hashi.Board).UnicodeString, nil
+	}
+	return (*hashi.Board).String, nil
+}
+
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
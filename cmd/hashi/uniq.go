@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func runUniq(args []string) error {
+	start := time.Now()
+	fs := newFlagSet("uniq")
+	timer := fs.Bool("t", false, "print execution time profile")
+	most := fs.Int("limit", 2, "stop counting after this many solutions (0 for no limit)")
+	limit := addLimitFlags(fs)
+	styles := addStyleFlags(fs)
+	puzzles := addPuzzleFlags(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	draw, err := styles.drawer()
+	if err != nil {
+		return err
+	}
+	if *most == 1 {
+		return fmt.Errorf("-limit must be 0 or at least 2; one solution cannot show that a puzzle is unique")
+	}
+	p, err := puzzles.loadPuzzle(args)
+	if err != nil {
+		return err
+	}
+	b := p.board
+	ctx, cancel := limit.context()
+	defer cancel()
+	ct, sols, err := b.CountSolutionsContext(ctx, *most, limit.limits)
+	switch {
+	case err != nil:
+		fmt.Printf("Solutions: at least %d (%v)\n", ct, err)
+	case ct == 0:
+		fmt.Printf("Solutions: 0\n")
+	case ct == *most:
+		fmt.Printf("Solutions: at least %d (stopped at limit)\n", ct)
+	case ct == 1:
+		fmt.Printf("Solutions: 1 (unique)\n")
+	default:
+		fmt.Printf("Solutions: %d\n", ct)
+	}
+	if ct == 1 && err == nil {
+		fmt.Printf("%s\n", draw(sols[0]))
+	} else {
+		for idx := 0; idx < ct && idx < 2; idx++ {
+			fmt.Printf("Solution %d:\n%s\n", idx+1, draw(sols[idx]))
+		}
+	}
+	if *timer {
+		phases := b.Stats().Phases
+		phases["Load board"] += p.load
+		printProfile(phases, start)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bismuthsalamander/hashi"
+)
+
+func runVerify(args []string) error {
+	fs := newFlagSet("verify")
+	puzzles := addPuzzleFlags(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		printUsage()
+		return fmt.Errorf("expected a problem file and a solution file, got %d arguments", len(args))
+	}
+	b, err := puzzles.loadBoard(args[:1])
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(args[1])
+	if err != nil {
+		return fmt.Errorf("error loading solution: %s", err)
+	}
+	v, err := hashi.VerifySolution(b, string(data))
+	if err != nil {
+		return fmt.Errorf("error reading solution: %s", err)
+	}
+	if v.Solved {
+		fmt.Printf("Valid solution\n")
+		return nil
+	}
+	for _, violation := range v.Violations {
+		fmt.Printf("%s\n", violation.String())
+	}
+	return fmt.Errorf("invalid solution: %d violations", len(v.Violations))
+}
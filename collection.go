@@ -0,0 +1,267 @@
+package hashi
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Entry is one puzzle from a collection file.
+type Entry struct {
+	// Index is the entry's position in its collection, starting at 1.
+	Index      int
+	ID         string
+	Title      string
+	Author     string
+	Difficulty string
+	Source     string
+	// Meta holds any other header fields, keyed by lowercased name.
+	Meta map[string]string
+	// Puzzle is the puzzle in the text grid format.
+	Puzzle string
+	// PuzzleLine is the line of the file that Puzzle starts on.
+	PuzzleLine int
+	// Solution is the expected solution, drawn the way String draws it,
+	// or "" if the entry does not have one.
+	Solution string
+	// Line is the line of the file that the entry starts on.
+	Line int
+}
+
+// Name identifies the entry by its ID if it has one and by its index
+// otherwise.
+func (e *Entry) Name() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return fmt.Sprintf("#%d", e.Index)
+}
+
+// Board parses the entry's puzzle.
+func (e *Entry) Board() (*Board, error) {
+	b, err := Parse(e.Puzzle)
+	if err != nil {
+		return nil, fmt.Errorf("entry %s: %w", e.Name(), err)
+	}
+	return b, nil
+}
+
+// BoardStrict is Board using ParseStrict, with any diagnostics moved to
+// the entry's place in its file.
+func (e *Entry) BoardStrict() (*Board, error) {
+	if diags := e.Lint(); len(diags) > 0 {
+		return nil, fmt.Errorf("entry %s: %w", e.Name(), &ParseError{diags})
+	}
+	return e.Board()
+}
+
+// Lint runs LintGrid on the entry's puzzle, numbering lines as they are in
+// its file. A JSON puzzle has no diagnostics.
+func (e *Entry) Lint() []Diagnostic {
+	if strings.HasPrefix(e.Puzzle, "{") {
+		return nil
+	}
+	diags := LintGrid(e.Puzzle)
+	for idx := range diags {
+		diags[idx].Line += e.PuzzleLine - 1
+	}
+	return diags
+}
+
+// Matches reports whether b has the same bridges as the entry's expected
+// solution. It returns an error if the entry has no solution or it cannot
+// be read.
+func (e *Entry) Matches(b *Board) (bool, error) {
+	if e.Solution == "" {
+		return false, fmt.Errorf("entry %s has no solution", e.Name())
+	}
+	want := b.emptyCopy()
+	if err := want.ApplySolution(e.Solution); err != nil {
+		return false, fmt.Errorf("entry %s: solution: %w", e.Name(), err)
+	}
+	for _, r := range want.AllRivers {
+		if b.Counterpart(r).Bridges != r.Bridges {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ParseCollection reads a collection: one or more puzzles, each with an
+// optional header of "# key: value" lines before its grid. The keys id,
+// title, author, difficulty and source fill in the matching Entry fields and
+// any others go in Meta. A "# solution" line after a grid starts the
+// entry's expected solution, and the next header line starts a new entry.
+// Other lines starting with '#' are comments. A plain problem file reads as
+// a collection of one.
+func ParseCollection(data string) ([]*Entry, error) {
+	entries := []*Entry{}
+	var e *Entry
+	var puzzle, solution []string
+	inSolution := false
+	finish := func() error {
+		if e == nil {
+			return nil
+		}
+		e.Puzzle = strings.Join(trimBlankLines(puzzle), "\n")
+		e.Solution = strings.Join(trimBlankLines(solution), "\n")
+		if e.Puzzle == "" {
+			return fmt.Errorf("line %d: entry %s has no puzzle", e.Line, e.Name())
+		}
+		for _, other := range entries {
+			if e.ID != "" && other.ID == e.ID {
+				return fmt.Errorf("line %d: entry ID %q is already used on line %d", e.Line, e.ID, other.Line)
+			}
+		}
+		entries = append(entries, e)
+		return nil
+	}
+	start := func(ln int) error {
+		if err := finish(); err != nil {
+			return err
+		}
+		e = &Entry{Index: len(entries) + 1, Meta: map[string]string{}, Line: ln}
+		puzzle, solution, inSolution = nil, nil, false
+		return nil
+	}
+
+	for idx, line := range strings.Split(data, "\n") {
+		ln := idx + 1
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "#") {
+			text := strings.TrimSpace(line[1:])
+			key, value, isField := strings.Cut(text, ":")
+			key = strings.ToLower(strings.TrimSpace(key))
+			value = strings.TrimSpace(value)
+			if key == "solution" && value == "" {
+				if e == nil || len(trimBlankLines(puzzle)) == 0 {
+					return nil, fmt.Errorf("line %d: solution comes before any puzzle", ln)
+				}
+				inSolution = true
+				continue
+			}
+			if !isField || key == "" || strings.ContainsAny(key, " \t") {
+				continue
+			}
+			if e == nil || len(trimBlankLines(puzzle)) > 0 {
+				if err := start(ln); err != nil {
+					return nil, err
+				}
+			}
+			e.setField(key, value)
+			continue
+		}
+		if e == nil {
+			if line == "" {
+				continue
+			}
+			if err := start(ln); err != nil {
+				return nil, err
+			}
+		}
+		if inSolution {
+			solution = append(solution, line)
+		} else {
+			if e.PuzzleLine == 0 && line != "" {
+				e.PuzzleLine = ln
+			}
+			puzzle = append(puzzle, line)
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("collection has no puzzles")
+	}
+	return entries, nil
+}
+
+func (e *Entry) setField(key string, value string) {
+	switch key {
+	case "id":
+		e.ID = value
+	case "title":
+		e.Title = value
+	case "author":
+		e.Author = value
+	case "difficulty":
+		e.Difficulty = value
+	case "source":
+		e.Source = value
+	default:
+		e.Meta[key] = value
+	}
+}
+
+// trimBlankLines drops the empty lines from both ends of lines. Lines of
+// spaces are kept, since they are rows of water.
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// ReadCollection reads a collection from the named file.
+func ReadCollection(fn string) ([]*Entry, error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCollection(string(data))
+}
+
+// SelectEntry finds the entry with the given ID or, failing that, the given
+// index, counting from 1.
+func SelectEntry(entries []*Entry, sel string) (*Entry, error) {
+	for _, e := range entries {
+		if e.ID == sel {
+			return e, nil
+		}
+	}
+	if n, err := strconv.Atoi(sel); err == nil && n >= 1 && n <= len(entries) {
+		return entries[n-1], nil
+	}
+	return nil, fmt.Errorf("no entry %q in a collection of %d", sel, len(entries))
+}
+
+// FormatCollection writes entries in the format ParseCollection reads.
+func FormatCollection(entries []*Entry) string {
+	out := strings.Builder{}
+	for idx, e := range entries {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		fields := [][2]string{{"id", e.ID}, {"title", e.Title}, {"author", e.Author}, {"difficulty", e.Difficulty}, {"source", e.Source}}
+		keys := []string{}
+		for k := range e.Meta {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fields = append(fields, [2]string{k, e.Meta[k]})
+		}
+		wrote := false
+		for _, f := range fields {
+			if f[1] != "" {
+				fmt.Fprintf(&out, "# %s: %s\n", f[0], f[1])
+				wrote = true
+			}
+		}
+		if !wrote && idx > 0 {
+			//without a header, this entry would run into the last one
+			fmt.Fprintf(&out, "# id: %d\n", idx+1)
+		}
+		fmt.Fprintf(&out, "%s\n", e.Puzzle)
+		if e.Solution != "" {
+			fmt.Fprintf(&out, "# solution\n%s\n", e.Solution)
+		}
+	}
+	return out.String()
+}
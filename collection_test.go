@@ -0,0 +1,122 @@
+package hashi
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testCollection = `# a comment before any entry
+# id: p1
+# title: The first one
+# Difficulty: easy
+# rating: 3
+2.2
+...
+2.2
+# solution
+2-2
+| |
+2-2
+
+# a comment between entries
+# id: p2
+1.1
+`
+
+func TestCollectionFields(t *testing.T) {
+	entries, err := ParseCollection(testCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("read %d entries, want 2", len(entries))
+	}
+	e := entries[0]
+	if e.Index != 1 || e.ID != "p1" || e.Title != "The first one" || e.Difficulty != "easy" || e.Line != 2 {
+		t.Errorf("first entry: %+v", e)
+	}
+	if !reflect.DeepEqual(e.Meta, map[string]string{"rating": "3"}) {
+		t.Errorf("extra fields: %v", e.Meta)
+	}
+	if e.Puzzle != "2.2\n...\n2.2" || e.Solution != "2-2\n| |\n2-2" {
+		t.Errorf("puzzle %q, solution %q", e.Puzzle, e.Solution)
+	}
+	if entries[1].ID != "p2" || entries[1].Puzzle != "1.1" || entries[1].Solution != "" {
+		t.Errorf("second entry: %+v", entries[1])
+	}
+	again, err := ParseCollection(FormatCollection(entries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx := range entries {
+		entries[idx].Line, again[idx].Line = 0, 0
+		entries[idx].PuzzleLine, again[idx].PuzzleLine = 0, 0
+		if !reflect.DeepEqual(again[idx], entries[idx]) {
+			t.Errorf("entry %d came back as %+v, want %+v", idx+1, again[idx], entries[idx])
+		}
+	}
+}
+
+func TestCollectionMatches(t *testing.T) {
+	entries, err := ParseCollection(testCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := entries[0].Board()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := entries[0].Matches(b); ok || err != nil {
+		t.Errorf("unsolved board: %v, %v", ok, err)
+	}
+	if ok, err := entries[0].Matches(Solve(b).Board); !ok || err != nil {
+		t.Errorf("solved board: %v, %v", ok, err)
+	}
+	if _, err := entries[1].Matches(b); err == nil {
+		t.Errorf("an entry without a solution matched")
+	}
+}
+
+func TestCollectionHeaderless(t *testing.T) {
+	//a plain problem file is a collection of one
+	entries, err := ParseCollection("\n2.2\n...\n2.2\n")
+	if err != nil || len(entries) != 1 || entries[0].Name() != "#1" {
+		t.Fatalf("plain file: %v, %v", entries, err)
+	}
+	//an entry without a header needs one to stay separate from the last
+	entries = append(entries, &Entry{Index: 2, Puzzle: "1.1"})
+	again, err := ParseCollection(FormatCollection(entries))
+	if err != nil || len(again) != 2 || again[1].Puzzle != "1.1" {
+		t.Errorf("headerless entries: %v, %v", again, err)
+	}
+}
+
+func TestSelectEntry(t *testing.T) {
+	entries, err := ParseCollection(testCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for sel, want := range map[string]int{"p2": 1, "1": 0, "2": 1} {
+		if e, err := SelectEntry(entries, sel); err != nil || e != entries[want] {
+			t.Errorf("SelectEntry(%q): %v, %v", sel, e, err)
+		}
+	}
+	for _, sel := range []string{"3", "0", "p3"} {
+		if _, err := SelectEntry(entries, sel); err == nil {
+			t.Errorf("SelectEntry(%q) found an entry", sel)
+		}
+	}
+}
+
+func TestCollectionErrors(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"# id: a\n",
+		"# solution\n2-2\n",
+		"# id: a\n2.2\n# id: a\n2.2\n",
+	} {
+		if _, err := ParseCollection(data); err == nil {
+			t.Errorf("%q: no error", data)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package hashi
+
+import "fmt"
+
+// Names of the built-in deduction rules, as recorded in Move.Rule.
+const (
+	RuleRequiredFill    = "RequiredFill"
+	RuleJoinedIsolation = "CapToAvoidJoinedIsolation"
+	RuleSelfIsolation   = "CapToAvoidSelfIsolation"
+	RuleBadCorners      = "BadCorners"
+	RuleMakeAGuess      = "MakeAGuess"
+	RuleSearch          = "Search"
+	// RuleMustProvide is recorded for moves made by calling MustProvide
+	// directly rather than through one of the rules.
+	RuleMustProvide = "MustProvide"
+)
+
+type MoveKind int
+
+const (
+	// AddBridges moves put bridges on River, leaving it with Count bridges.
+	AddBridges MoveKind = iota
+	// CapRiver moves limit River to at most Count more bridges.
+	CapRiver
+)
+
+// Move is one change the solver made to a board, along with the rule that
+// justified it and a sentence explaining why.
+type Move struct {
+	Rule    string
+	Kind    MoveKind
+	River   *River
+	Count   int
+	Islands []*Island
+	Reason  string
+}
+
+// Action describes what the move does, without the rule or reason.
+func (m Move) Action() string {
+	if m.Kind == AddBridges {
+		return fmt.Sprintf("bridge %s up to %d", riverLabel(m.River), m.Count)
+	}
+	return fmt.Sprintf("cap %s at %d more", riverLabel(m.River), m.Count)
+}
+
+func (m Move) String() string {
+	return fmt.Sprintf("%s: %s (%s)", m.Rule, m.Action(), m.Reason)
+}
+
+// addMove counts a move by rule and, when b is recording an explanation,
+// appends a Move describing r's current state to b.Moves. why is only called
+// in that case, so callers can build the reason lazily.
+func (b *Board) addMove(rule string, kind MoveKind, r *River, islands []*Island, why func() string) {
+	b.countMove(rule)
+	if !b.Explain {
+		return
+	}
+	m := Move{Rule: rule, Kind: kind, River: r, Islands: []*Island{}, Reason: why()}
+	if kind == AddBridges {
+		m.Count = r.Bridges
+	} else {
+		m.Count = r.ToGive
+	}
+	for _, i := range append(islands, r.Islands...) {
+		seen := false
+		for _, prev := range m.Islands {
+			seen = seen || prev == i
+		}
+		if !seen {
+			m.Islands = append(m.Islands, i)
+		}
+	}
+	b.record(undo{kind: undoMove})
+	b.Moves = append(b.Moves, m)
+}
+
+// RecordMove records a move that rule made on river r, for rules outside
+// this package. The move counts toward the rule in Stats and, when b.Explain
+// is set, is appended to b.Moves with the given reason. It must be called
+// after the change, since the move describes r's current state.
+func (b *Board) RecordMove(rule string, kind MoveKind, r *River, reason string) {
+	b.addMove(rule, kind, r, nil, func() string {
+		return reason
+	})
+}
+
+// onBoard returns a copy of m that refers to the islands and river of b,
+// which must be a copy of the board m was made on.
+func (m Move) onBoard(b *Board) Move {
+	out := m
+	out.River = b.Counterpart(m.River)
+	out.Islands = make([]*Island, len(m.Islands))
+	for idx, i := range m.Islands {
+		out.Islands[idx] = b.Grid[i.R][i.C]
+	}
+	return out
+}
+
+func islandLabel(i *Island) string {
+	return fmt.Sprintf("the %d at (r%d, c%d)", i.Num, i.R, i.C)
+}
+
+func riverLabel(r *River) string {
+	return fmt.Sprintf("(r%d, c%d)-(r%d, c%d)", r.Islands[0].R, r.Islands[0].C, r.Islands[1].R, r.Islands[1].C)
+}
@@ -0,0 +1,209 @@
+package hashi
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// GenOptions controls Generate.
+type GenOptions struct {
+	Rows int
+	Cols int
+	// Density is the fraction of cells that should hold islands. Crowded
+	// grids may end up with fewer islands than asked for.
+	Density float64
+	Seed    int64
+	// MaxAttempts caps how many layouts Generate tries before giving up on
+	// finding a uniquely solvable one; 0 means 1000.
+	MaxAttempts int
+	// MinLevel makes Generate keep only puzzles that Rate puts at this
+	// Level or higher. Most layouts only need levels 1 and 2; about one in
+	// twenty needs MakeAGuess (level 4), and fewer still need the search,
+	// so high levels may need a larger MaxAttempts.
+	MinLevel int
+}
+
+// layout is a board under construction: islands, the bridges between them
+// and the cells those bridges cover.
+type layout struct {
+	rows, cols int
+	island     [][]int
+	horizontal [][]bool
+	vertical   [][]bool
+	cells      [][2]int
+	nums       []int
+}
+
+var directions = [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+
+// Generate builds a random puzzle that has exactly one solution. It grows a
+// connected layout of bridges from a single island, derives each island's
+// number from the bridges that touch it, and keeps the first layout the
+// solver can show to be unique and that rates at least opts.MinLevel.
+func Generate(opts GenOptions) (*Board, error) {
+	if opts.Rows < 1 || opts.Cols < 1 || opts.Rows*opts.Cols < 3 {
+		return nil, fmt.Errorf("a %dx%d board is too small for a puzzle", opts.Rows, opts.Cols)
+	}
+	if opts.Density <= 0 || opts.Density > 1 {
+		return nil, fmt.Errorf("density must be in (0, 1], got %v", opts.Density)
+	}
+	attempts := opts.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1000
+	}
+	rng := rand.New(rand.NewSource(opts.Seed))
+	target := max(2, int(opts.Density*float64(opts.Rows*opts.Cols)+0.5))
+	for a := 0; a < attempts; a++ {
+		l := newLayout(opts.Rows, opts.Cols)
+		l.grow(rng, target)
+		if len(l.cells) < 2 {
+			continue
+		}
+		l.addLoops(rng)
+		b, err := BoardFromString(l.String())
+		if err != nil {
+			return nil, err
+		}
+		if ct, _ := b.CountSolutions(2); ct != 1 {
+			continue
+		}
+		if opts.MinLevel > 0 {
+			if rating, err := Rate(b); err != nil || rating.Level < opts.MinLevel {
+				continue
+			}
+		}
+		return b, nil
+	}
+	if opts.MinLevel > 0 {
+		return nil, fmt.Errorf("no uniquely solvable puzzle of level %d or higher found in %d attempts", opts.MinLevel, attempts)
+	}
+	return nil, fmt.Errorf("no uniquely solvable puzzle found in %d attempts", attempts)
+}
+
+func newLayout(rows int, cols int) *layout {
+	l := &layout{rows: rows, cols: cols}
+	for r := 0; r < rows; r++ {
+		l.island = append(l.island, make([]int, cols))
+		l.horizontal = append(l.horizontal, make([]bool, cols))
+		l.vertical = append(l.vertical, make([]bool, cols))
+		for c := 0; c < cols; c++ {
+			l.island[r][c] = -1
+		}
+	}
+	return l
+}
+
+func (l *layout) inside(r int, c int) bool {
+	return r >= 0 && r < l.rows && c >= 0 && c < l.cols
+}
+
+func (l *layout) free(r int, c int) bool {
+	return l.island[r][c] < 0 && !l.horizontal[r][c] && !l.vertical[r][c]
+}
+
+func (l *layout) addIsland(r int, c int) {
+	l.island[r][c] = len(l.cells)
+	l.cells = append(l.cells, [2]int{r, c})
+	l.nums = append(l.nums, 0)
+}
+
+// bridge lays ct bridges from island idx in direction d to the island or
+// empty cell dist cells away, which must already have been checked.
+func (l *layout) bridge(idx int, d [2]int, dist int, ct int) {
+	r, c := l.cells[idx][0], l.cells[idx][1]
+	for step := 1; step < dist; step++ {
+		if d[0] == 0 {
+			l.horizontal[r+d[0]*step][c+d[1]*step] = true
+		} else {
+			l.vertical[r+d[0]*step][c+d[1]*step] = true
+		}
+	}
+	er, ec := r+d[0]*dist, c+d[1]*dist
+	if l.island[er][ec] < 0 {
+		l.addIsland(er, ec)
+	}
+	l.nums[idx] += ct
+	l.nums[l.island[er][ec]] += ct
+}
+
+// grow adds islands one bridge at a time until there are target of them or
+// too many tries in a row have failed to find room.
+func (l *layout) grow(rng *rand.Rand, target int) {
+	l.addIsland(rng.Intn(l.rows), rng.Intn(l.cols))
+	for fails := 0; len(l.cells) < target && fails < 200; {
+		idx := rng.Intn(len(l.cells))
+		d := directions[rng.Intn(len(directions))]
+		dist := 2 + rng.Intn(max(1, max(l.rows, l.cols)/2))
+		if !l.canExtend(idx, d, dist) {
+			fails++
+			continue
+		}
+		fails = 0
+		l.bridge(idx, d, dist, 1+rng.Intn(2))
+	}
+}
+
+// canExtend reports whether a new island can go dist cells from island idx
+// in direction d, with a clear path between them and no island right next
+// to it.
+func (l *layout) canExtend(idx int, d [2]int, dist int) bool {
+	r, c := l.cells[idx][0], l.cells[idx][1]
+	er, ec := r+d[0]*dist, c+d[1]*dist
+	if !l.inside(er, ec) {
+		return false
+	}
+	for step := 1; step <= dist; step++ {
+		if !l.free(r+d[0]*step, c+d[1]*step) {
+			return false
+		}
+	}
+	for _, nd := range directions {
+		nr, nc := er+nd[0], ec+nd[1]
+		if l.inside(nr, nc) && l.island[nr][nc] >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// addLoops puts extra bridges between some pairs of islands that face each
+// other across open water, so that the layout is not always a tree.
+func (l *layout) addLoops(rng *rand.Rand) {
+	for idx := range l.cells {
+		for _, d := range directions[:2] {
+			if rng.Intn(3) != 0 || l.nums[idx] >= 7 {
+				continue
+			}
+			r, c := l.cells[idx][0], l.cells[idx][1]
+			for dist := 1; l.inside(r+d[0]*dist, c+d[1]*dist); dist++ {
+				nr, nc := r+d[0]*dist, c+d[1]*dist
+				if other := l.island[nr][nc]; other >= 0 {
+					if dist > 1 && l.nums[other] < 7 {
+						l.bridge(idx, d, dist, 1)
+					}
+					break
+				}
+				if !l.free(nr, nc) {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (l *layout) String() string {
+	lines := []string{}
+	for r := 0; r < l.rows; r++ {
+		line := ""
+		for c := 0; c < l.cols; c++ {
+			if idx := l.island[r][c]; idx >= 0 {
+				line += fmt.Sprintf("%d", l.nums[idx])
+			} else {
+				line += "."
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
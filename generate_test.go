@@ -0,0 +1,63 @@
+package hashi
+
+import "testing"
+
+func TestGenerateUnique(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		b, err := Generate(GenOptions{Rows: 7, Cols: 7, Density: 0.3, Seed: seed})
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if ct, _ := b.CountSolutions(0); ct != 1 {
+			t.Errorf("seed %d: %d solutions\n%s", seed, ct, b.PuzzleString())
+		}
+		again, err := BoardFromString(b.PuzzleString())
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if again.PuzzleString() != b.PuzzleString() {
+			t.Errorf("seed %d: read back\n%s\nwant\n%s", seed, again.PuzzleString(), b.PuzzleString())
+		}
+	}
+}
+
+func TestGenerateSeed(t *testing.T) {
+	opts := GenOptions{Rows: 10, Cols: 10, Density: 0.3, Seed: 42}
+	first, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.PuzzleString() != second.PuzzleString() {
+		t.Errorf("seed 42 gave\n%s\nand then\n%s", first.PuzzleString(), second.PuzzleString())
+	}
+}
+
+func TestGenerateMinLevel(t *testing.T) {
+	b, err := Generate(GenOptions{Rows: 10, Cols: 10, Density: 0.3, Seed: 1, MinLevel: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rating, err := Rate(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rating.Level < 4 {
+		t.Errorf("level %d puzzle, want 4 or higher\n%s", rating.Level, b.PuzzleString())
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, opts := range []GenOptions{
+		{Rows: 1, Cols: 2, Density: 0.5},
+		{Rows: 7, Cols: 7, Density: 0},
+		{Rows: 7, Cols: 7, Density: 1.5},
+	} {
+		if _, err := Generate(opts); err == nil {
+			t.Errorf("%+v: no error", opts)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package hashi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+)
+
+// GIFOptions configures Board.ReplayGIF.
+type GIFOptions struct {
+	//CellSize is the width and height of a grid cell in pixels; 0 means 40
+	CellSize int
+	//Delay is how long each frame shows, in hundredths of a second; 0
+	//means 50. The last frame shows four times as long.
+	Delay int
+}
+
+// gifPalette holds every color Image draws, so frames convert exactly.
+var gifPalette = color.Palette{paperColor, inkColor, faintColor, errorColor, errorFill, highlightColor, capColor}
+
+// ReplayGIF writes an animated GIF that replays b.Moves from the empty
+// puzzle, so b should have been solved with Explain set. Each frame shows
+// the board after one move, with the river the move changed picked out as
+// Image's Highlight does, and names the move's rule and action beneath.
+func (b *Board) ReplayGIF(w io.Writer, opts GIFOptions) error {
+	cs := opts.CellSize
+	if cs <= 0 {
+		cs = 40
+	}
+	delay := opts.Delay
+	if delay <= 0 {
+		delay = 50
+	}
+	scale := max(1, cs/20)
+	captions := [][]string{{"Start", fmt.Sprintf("%d moves", len(b.Moves))}}
+	for idx, m := range b.Moves {
+		captions = append(captions, []string{fmt.Sprintf("%d/%d %s", idx+1, len(b.Moves), m.Rule), m.Action()})
+	}
+	//every frame is as wide as the widest caption needs
+	width := b.Cols * cs
+	for _, lines := range captions {
+		for _, line := range lines {
+			width = max(width, textWidth(line, scale)+8*scale)
+		}
+	}
+
+	rb := b.emptyCopy()
+	anim := &gif.GIF{}
+	addFrame := func(highlight *River, lines []string) {
+		board := rb.Image(PNGOptions{Mode: DrawState, CellSize: cs, Highlight: highlight})
+		anim.Image = append(anim.Image, captionFrame(board, width, lines, scale))
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	addFrame(nil, captions[0])
+	for idx, m := range b.Moves {
+		r := rb.Counterpart(m.River)
+		if r == nil {
+			return fmt.Errorf("move %d: %s is not on this board", idx+1, riverLabel(m.River))
+		}
+		switch m.Kind {
+		case AddBridges:
+			for r.Bridges < m.Count {
+				if err := rb.AddBridge(r); err != nil {
+					return fmt.Errorf("move %d: %s", idx+1, err)
+				}
+			}
+		case CapRiver:
+			r.CapToGive(m.Count)
+		}
+		addFrame(r, captions[idx+1])
+	}
+	status := "Solved"
+	if ok, _ := rb.IsSolved(); !ok {
+		status = "Not solved"
+	}
+	addFrame(nil, []string{status, captions[0][1]})
+	anim.Delay[len(anim.Delay)-1] = 4 * delay
+	return gif.EncodeAll(w, anim)
+}
+
+// captionFrame returns board, centered in a frame width pixels wide, with
+// lines of text written beneath it, as a paletted image for a GIF frame.
+func captionFrame(board *image.RGBA, width int, lines []string, scale int) *image.Paletted {
+	pad := 4 * scale
+	lineHeight := 9 * scale
+	height := board.Bounds().Dy() + len(lines)*lineHeight + 2*pad
+	frame := image.NewPaletted(image.Rect(0, 0, width, height), gifPalette)
+	draw.Draw(frame, frame.Bounds(), image.NewUniform(paperColor), image.Point{}, draw.Src)
+	left := (width - board.Bounds().Dx()) / 2
+	draw.Draw(frame, board.Bounds().Add(image.Pt(left, 0)), board, image.Point{}, draw.Src)
+	for idx, line := range lines {
+		drawText(frame, image.Pt(pad, board.Bounds().Dy()+pad+idx*lineHeight), line, scale, inkColor)
+	}
+	return frame
+}
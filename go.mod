@@ -1,5 +1,3 @@
 module github.com/bismuthsalamander/hashi
 
 go 1.20
-
-require github.com/bismuthsalamander/stopwatch v0.1.0
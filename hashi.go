@@ -0,0 +1,73 @@
+// Package hashi solves hashiwokakero ("bridges") puzzles.
+//
+// A puzzle is a rectangular grid of islands, each labeled with the number of
+// bridges it needs. Bridges run horizontally or vertically between adjacent
+// islands, at most two per pair, without crossing one another, and all of the
+// islands must end up in one connected group.
+//
+// Most callers only need Parse (or ParseFile), Solve and Verify. The Board,
+// Island and River types are exported for callers that want to inspect or
+// drive the solver one deduction at a time.
+//
+// Solve, SolveContext and Verify only read their arguments, so any number of
+// them can run at once, even on the same puzzle. A Board's methods are not
+// safe for concurrent use, though: a board that one goroutine is changing
+// must not be used by another.
+package hashi
+
+import (
+	"context"
+	"strings"
+)
+
+// Parse reads a puzzle in the text grid format: one line per row, with the
+// digits 1-8 marking islands and any other character marking open water.
+// Data that starts with '{' is read as JSON instead; see BoardFromJSON.
+func Parse(data string) (*Board, error) {
+	if strings.HasPrefix(strings.TrimSpace(data), "{") {
+		return BoardFromJSON([]byte(data))
+	}
+	return BoardFromString(data)
+}
+
+// ParseFile reads a puzzle from the named file, in either of the formats
+// Parse reads.
+func ParseFile(fn string) (*Board, error) {
+	return GetBoardFromFile(fn)
+}
+
+// Result describes the outcome of a call to Solve or Board.AutoSolve.
+type Result struct {
+	// Solved reports whether Board is a complete, valid solution.
+	Solved bool
+	// Board is the solver's final state. It is a solution when Solved is
+	// true and the furthest the solver got otherwise.
+	Board *Board
+	// Reason explains why Board is not a solution; it is nil when Solved
+	// is true.
+	Reason error
+	// Interrupted reports that the solve stopped early because its context
+	// was done or it reached one of its limits. Board is then the deduction
+	// state the solver had reached and Reason wraps ErrInterrupted.
+	Interrupted bool
+	// Stats describes the moves behind Board and the work it took.
+	Stats Stats
+}
+
+// Solve solves a copy of the puzzle, leaving the argument untouched. It runs
+// the deduction rules first and falls back to a full search when they stall,
+// so a puzzle either comes back solved or with Reason set to ErrNoSolution.
+func Solve(puzzle *Board) *Result {
+	return SolveContext(context.Background(), puzzle, Limits{})
+}
+
+// SolveContext is like Solve, but gives up when ctx is done or the solve
+// reaches one of limits, returning a result with Interrupted set.
+func SolveContext(ctx context.Context, puzzle *Board, limits Limits) *Result {
+	b := puzzle.clone()
+	b.setBudget(ctx, limits)
+	defer func() {
+		b.budget = nil
+	}()
+	return b.AutoSolve(true)
+}
@@ -0,0 +1,31 @@
+package hashi
+
+// NextHint finds the first move b's rules would make from the board's
+// current state, without applying it or anything else. It returns
+// nil if the rules are stuck, and an error if the current state already
+// breaks the rules of the puzzle.
+func (b *Board) NextHint() (*Move, error) {
+	if m, err := b.HasMistakes(); m {
+		return nil, err
+	}
+	explain := b.Explain
+	b.Explain = true
+	defer func() {
+		b.Explain = explain
+	}()
+	for _, rule := range b.activeRules() {
+		cp := b.Checkpoint()
+		start := len(b.Moves)
+		b.applyRule(rule)
+		var hint *Move
+		if len(b.Moves) > start {
+			m := b.Moves[start]
+			hint = &m
+		}
+		b.Rollback(cp)
+		if hint != nil {
+			return hint, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+package hashi
+
+import "fmt"
+
+// TODO: do we need to index rivers by direction?
+type Island struct {
+	Num        int
+	Bridges    int
+	Available  int
+	R          int
+	C          int
+	Rivers     []*River
+	LiveRivers []*River
+	//Index is the island's position in Board.AllIslands
+	Index int
+	board *Board
+}
+
+// Cluster returns the group of islands that i is currently joined to.
+func (i *Island) Cluster() *Cluster {
+	f := &i.board.forest
+	return f.views[f.find(i.Index)]
+}
+
+func (i *Island) NumNeeded() int {
+	return i.Num - i.Bridges
+}
+
+func (i *Island) IsComplete() bool {
+	return i.Num == i.Bridges
+}
+
+func (i *Island) Update() {
+	riversToUpdate := []*River{}
+	newBridges := 0
+	newAvailable := 0
+	newLiveRivers := []*River{}
+	//Update bridges, then river ToGives, then Available
+	for _, r := range i.Rivers {
+		newBridges += r.Bridges
+	}
+	i.board.setBridges(i, newBridges)
+	for _, r := range i.Rivers {
+		newToGive := max(0, min(i.NumNeeded(), r.ToGive))
+		if newToGive != r.ToGive {
+			riversToUpdate = append(riversToUpdate, r)
+			i.board.setToGive(r, newToGive)
+		}
+	}
+	for _, r := range i.Rivers {
+		newAvailable += r.ToGive
+		if r.ToGive > 0 {
+			newLiveRivers = append(newLiveRivers, r)
+		}
+	}
+	i.board.setLiveRivers(i, newLiveRivers)
+	i.board.setInt(&i.Available, newAvailable)
+	for _, r := range riversToUpdate {
+		r.Neighbor(i).Update()
+	}
+}
+
+// get the pointer to this island's river with island other, or nil
+func (i *Island) RiverWith(other *Island) *River {
+	for _, r := range i.Rivers {
+		if r.Connects(other) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (i *Island) addRiver(r *River) {
+	i.Rivers = append(i.Rivers, r)
+	i.LiveRivers = append(i.LiveRivers, r)
+}
+
+func (i *Island) String() string {
+	return fmt.Sprintf("[%d/%d] (r%d, c%d) a%d", i.Bridges, i.Num, i.R, i.C, i.Available)
+}
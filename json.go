@@ -0,0 +1,133 @@
+package hashi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonBoard is the JSON form of a board:
+//
+//	{
+//	  "rows": 3, "cols": 3, "maxBridges": 2,
+//	  "islands": [{"row": 0, "col": 0, "num": 1}, ...],
+//	  "rivers": [{"from": [0, 0], "to": [0, 2], "bridges": 1, "toGive": 0}, ...]
+//	}
+//
+// rivers may be left out, or list only some of the rivers; those missing
+// start out empty, as they do in a fresh puzzle.
+type jsonBoard struct {
+	Rows int `json:"rows"`
+	Cols int `json:"cols"`
+	//MaxBridges is left out when it is the usual 2
+	MaxBridges int          `json:"maxBridges,omitempty"`
+	Islands    []jsonIsland `json:"islands"`
+	Rivers     []jsonRiver  `json:"rivers,omitempty"`
+}
+
+type jsonIsland struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+	Num int `json:"num"`
+}
+
+type jsonRiver struct {
+	From    [2]int `json:"from"`
+	To      [2]int `json:"to"`
+	Bridges int    `json:"bridges"`
+	ToGive  int    `json:"toGive"`
+}
+
+// MarshalJSON encodes the puzzle along with every river's bridges and
+// ToGive cap, so a solving state comes back exactly from BoardFromJSON.
+func (b *Board) MarshalJSON() ([]byte, error) {
+	jb := b.puzzleJSON()
+	for _, r := range b.AllRivers {
+		jb.Rivers = append(jb.Rivers, jsonRiver{
+			From:    [2]int{r.Islands[0].R, r.Islands[0].C},
+			To:      [2]int{r.Islands[1].R, r.Islands[1].C},
+			Bridges: r.Bridges,
+			ToGive:  r.ToGive,
+		})
+	}
+	return json.Marshal(jb)
+}
+
+// PuzzleJSON encodes just the puzzle, without any bridges; it is the JSON
+// counterpart of PuzzleString.
+func (b *Board) PuzzleJSON() ([]byte, error) {
+	return json.Marshal(b.puzzleJSON())
+}
+
+func (b *Board) puzzleJSON() *jsonBoard {
+	jb := &jsonBoard{Rows: b.Rows, Cols: b.Cols, Islands: []jsonIsland{}}
+	for ri := 0; ri < b.Rows; ri++ {
+		for ci := 0; ci < b.Cols; ci++ {
+			if i := b.Grid[ri][ci]; i != nil {
+				jb.Islands = append(jb.Islands, jsonIsland{Row: i.R, Col: i.C, Num: i.Num})
+			}
+		}
+	}
+	if b.MaxBridges != 2 {
+		jb.MaxBridges = b.MaxBridges
+	}
+	return jb
+}
+
+// BoardFromJSON reads a board written by MarshalJSON or PuzzleJSON. Bridges
+// are placed as listed, even if they break the rules, so that mistaken
+// solutions can be read in and checked.
+func BoardFromJSON(data []byte) (*Board, error) {
+	jb := jsonBoard{}
+	if err := json.Unmarshal(data, &jb); err != nil {
+		return nil, err
+	}
+	if jb.Rows <= 0 || jb.Cols <= 0 {
+		return nil, fmt.Errorf("board has %d rows and %d cols", jb.Rows, jb.Cols)
+	}
+	if jb.MaxBridges == 0 {
+		jb.MaxBridges = 2
+	}
+	if jb.MaxBridges < 1 || jb.MaxBridges > 2 {
+		return nil, fmt.Errorf("maxBridges is %d; must be 1 or 2", jb.MaxBridges)
+	}
+	b := Board{Grid: make([][]*Island, jb.Rows), Rows: jb.Rows, Cols: jb.Cols, MaxBridges: jb.MaxBridges, Clusters: []*Cluster{}, AllRivers: []*River{}, AllIslands: []*Island{}}
+	for ri := range b.Grid {
+		b.Grid[ri] = make([]*Island, b.Cols)
+	}
+	for idx, ji := range jb.Islands {
+		if ji.Row < 0 || ji.Row >= b.Rows || ji.Col < 0 || ji.Col >= b.Cols {
+			return nil, fmt.Errorf("island %d at (r%d, c%d) is off the board", idx, ji.Row, ji.Col)
+		}
+		if ji.Num < 1 || ji.Num > 4*jb.MaxBridges {
+			return nil, fmt.Errorf("island %d at (r%d, c%d) has num %d; must be 1-%d", idx, ji.Row, ji.Col, ji.Num, 4*jb.MaxBridges)
+		}
+		if b.Grid[ji.Row][ji.Col] != nil {
+			return nil, fmt.Errorf("island %d at (r%d, c%d) is listed twice", idx, ji.Row, ji.Col)
+		}
+		b.AddIsland(ji.Num, ji.Row, ji.Col)
+	}
+	b.CreateRivers()
+
+	rivers := make([]*River, len(jb.Rivers))
+	for idx, jr := range jb.Rivers {
+		ia, ib := b.IslandAt(jr.From[0], jr.From[1]), b.IslandAt(jr.To[0], jr.To[1])
+		if ia == nil || ib == nil {
+			return nil, fmt.Errorf("river %d: no island at both ends", idx)
+		}
+		rivers[idx] = ia.RiverWith(ib)
+		if rivers[idx] == nil {
+			return nil, fmt.Errorf("river %d: islands %s and %s are not adjacent", idx, ia, ib)
+		}
+		if jr.Bridges < 0 || jr.ToGive < 0 {
+			return nil, fmt.Errorf("river %d: bridges and toGive cannot be negative", idx)
+		}
+		if jr.Bridges > 0 {
+			b.forceBridges(rivers[idx], jr.Bridges)
+		}
+	}
+	//caps go on after all of the bridges, which can lower them further
+	for idx, jr := range jb.Rivers {
+		rivers[idx].CapToGive(jr.ToGive)
+	}
+	return &b, nil
+}
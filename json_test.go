@@ -0,0 +1,136 @@
+package hashi
+
+import "testing"
+
+// sameState fails unless got has want's puzzle and every river has the same
+// bridges and cap.
+func sameState(t *testing.T, got *Board, want *Board, where string) {
+	t.Helper()
+	if got.PuzzleString() != want.PuzzleString() || got.MaxBridges != want.MaxBridges {
+		t.Fatalf("%s: puzzle\n%s\n(max %d)\nwant\n%s\n(max %d)", where, got.PuzzleString(), got.MaxBridges, want.PuzzleString(), want.MaxBridges)
+	}
+	sameBridges(t, got, want, where)
+	for _, r := range want.AllRivers {
+		if other := got.Counterpart(r); other.ToGive != r.ToGive {
+			t.Fatalf("%s: %s can take %d more, want %d", where, r, other.ToGive, r.ToGive)
+		}
+	}
+}
+
+func jsonRoundTrip(t *testing.T, b *Board) *Board {
+	t.Helper()
+	data, err := b.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(string(data))
+	if err != nil {
+		t.Fatalf("Parse(%s): %v", data, err)
+	}
+	return got
+}
+
+func TestJSONStateWithCaps(t *testing.T) {
+	b := mustParse(t, "3.3\n...\n2.2\n")
+	b.AddBridge(b.Grid[0][0].RiverWith(b.Grid[0][2]))
+	//a cap below what the islands allow, and one to zero
+	b.Grid[0][0].RiverWith(b.Grid[2][0]).CapToGive(1)
+	b.Grid[2][0].RiverWith(b.Grid[2][2]).CapToGive(0)
+	sameState(t, jsonRoundTrip(t, b), b, "capped state")
+}
+
+func TestJSONCrossingCap(t *testing.T) {
+	b := mustParse(t, ".1.\n1.1\n.1.\n")
+	b.AddBridge(b.Grid[1][0].RiverWith(b.Grid[1][2]))
+	got := jsonRoundTrip(t, b)
+	sameState(t, got, b, "crossed state")
+	if r := got.Grid[0][1].RiverWith(got.Grid[2][1]); r.ToGive != 0 {
+		t.Errorf("crossed river can take %d bridges", r.ToGive)
+	}
+}
+
+func TestJSONKeepsMistakes(t *testing.T) {
+	b, err := BoardFromJSON([]byte(`{"rows": 1, "cols": 3,
+		"islands": [{"row": 0, "col": 0, "num": 1}, {"row": 0, "col": 2, "num": 1}],
+		"rivers": [{"from": [0, 0], "to": [0, 2], "bridges": 5, "toGive": 0}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := b.AllRivers[0]; r.Bridges != 5 {
+		t.Fatalf("river has %d bridges, want 5", r.Bridges)
+	}
+	if Verify(b).Solved {
+		t.Errorf("5 bridges on a river verified")
+	}
+	sameState(t, jsonRoundTrip(t, b), b, "mistaken state")
+}
+
+func TestJSONPuzzleOnly(t *testing.T) {
+	b := mustParse(t, "2.2\n...\n2.2\n")
+	b.AddBridge(b.AllRivers[0])
+	data, err := b.PuzzleJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := BoardFromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sameState(t, got, mustParse(t, "2.2\n...\n2.2\n"), "puzzle only")
+}
+
+func TestJSONMaxBridges(t *testing.T) {
+	b, err := BoardFromJSON([]byte(`{"rows": 1, "cols": 3, "maxBridges": 1, "islands": [{"row": 0, "col": 0, "num": 1}, {"row": 0, "col": 2, "num": 1}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := jsonRoundTrip(t, b)
+	if got.MaxBridges != 1 || got.AllRivers[0].Max != 1 {
+		t.Errorf("maxBridges came back as %d, river max %d", got.MaxBridges, got.AllRivers[0].Max)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	for _, bad := range []string{
+		`{"rows": 0, "cols": 3}`,
+		`{"rows": 1, "cols": 1, "islands": [{"row": 1, "col": 0, "num": 1}]}`,
+		`{"rows": 1, "cols": 1, "islands": [{"row": 0, "col": 0, "num": 9}]}`,
+		`{"rows": 1, "cols": 2, "islands": [{"row": 0, "col": 0, "num": 1}, {"row": 0, "col": 0, "num": 1}]}`,
+		`{"rows": 1, "cols": 3, "islands": [{"row": 0, "col": 0, "num": 1}], "rivers": [{"from": [0, 0], "to": [0, 2], "bridges": 1}]}`,
+		`{"rows": 1, "cols": 3`,
+		`{"rows": 1, "cols": 1, "maxBridges": 3}`,
+	} {
+		if _, err := BoardFromJSON([]byte(bad)); err == nil {
+			t.Errorf("%s: no error", bad)
+		}
+	}
+}
+
+func TestCloneKeepsJSONState(t *testing.T) {
+	b := mustParse(t, "3.3\n...\n2.2\n")
+	b.AddBridge(b.Grid[0][0].RiverWith(b.Grid[0][2]))
+	b.Grid[0][0].RiverWith(b.Grid[2][0]).CapToGive(1)
+	b.Grid[2][0].RiverWith(b.Grid[2][2]).CapToGive(0)
+	loaded := jsonRoundTrip(t, b)
+	sameState(t, loaded.clone(), b, "copy of a capped state")
+	if res := Solve(loaded); res.Solved {
+		t.Errorf("solved despite a cap that rules out the solution:\n%s", res.Board)
+	}
+}
+
+func TestSolveKeepsMistakes(t *testing.T) {
+	b, err := BoardFromJSON([]byte(`{"rows": 1, "cols": 3,
+		"islands": [{"row": 0, "col": 0, "num": 1}, {"row": 0, "col": 2, "num": 1}],
+		"rivers": [{"from": [0, 0], "to": [0, 2], "bridges": 5, "toGive": 0}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sameState(t, b.clone(), b, "copy of a mistaken state")
+	res := Solve(b)
+	if res.Solved {
+		t.Errorf("a river with 5 bridges came back solved:\n%s", res.Board)
+	}
+	if r := res.Board.AllRivers[0]; r.Bridges != 5 {
+		t.Errorf("river has %d bridges after solving, want 5", r.Bridges)
+	}
+}
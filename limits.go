@@ -0,0 +1,59 @@
+package hashi
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInterrupted = errors.New("interrupted")
+	ErrNodeLimit   = errors.New("search node limit reached")
+	ErrProbeLimit  = errors.New("probe limit reached")
+)
+
+// Limits caps the work a solve may do. Zero values mean no limit.
+type Limits struct {
+	// MaxNodes caps the number of states the search visits.
+	MaxNodes int
+	// MaxProbes caps the number of moves MakeAGuess tries out.
+	MaxProbes int
+}
+
+// budget is what the board checks against while solving under a context
+// or limits. The limits are stored as absolute counts of b.stats.
+type budget struct {
+	ctx       context.Context
+	maxNodes  int
+	maxProbes int
+	err       error
+}
+
+func (b *Board) setBudget(ctx context.Context, limits Limits) {
+	b.budget = &budget{ctx: ctx}
+	if limits.MaxNodes > 0 {
+		b.budget.maxNodes = b.stats.SearchNodes + limits.MaxNodes
+	}
+	if limits.MaxProbes > 0 {
+		b.budget.maxProbes = b.stats.Probes + limits.MaxProbes
+	}
+}
+
+// interrupted reports whether the current solve has to stop, either because
+// its context is done or because it has used up one of its limits.
+func (b *Board) interrupted() bool {
+	bud := b.budget
+	if bud == nil {
+		return false
+	}
+	if bud.err != nil {
+		return true
+	}
+	if err := bud.ctx.Err(); err != nil {
+		bud.err = err
+	} else if bud.maxNodes > 0 && b.stats.SearchNodes >= bud.maxNodes {
+		bud.err = ErrNodeLimit
+	} else if bud.maxProbes > 0 && b.stats.Probes >= bud.maxProbes {
+		bud.err = ErrProbeLimit
+	}
+	return bud.err != nil
+}
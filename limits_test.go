@@ -0,0 +1,84 @@
+package hashi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stalledBoard returns problem36 with only RequiredFill to run, so that
+// solving it needs the search.
+func stalledBoard(t *testing.T) *Board {
+	t.Helper()
+	b, err := ParseFile("problem36.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Rules = []Rule{LookupRule(RuleRequiredFill)}
+	return b
+}
+
+func TestSolveInterrupted(t *testing.T) {
+	res := Solve(stalledBoard(t))
+	if !res.Solved || res.Stats.SearchNodes == 0 {
+		t.Fatalf("solved %v after %d search nodes; want a solve that searches", res.Solved, res.Stats.SearchNodes)
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, tc := range []struct {
+		name   string
+		ctx    context.Context
+		limits Limits
+		//a cancelled context stops the rules before their first move
+		moved bool
+	}{
+		{"node limit", context.Background(), Limits{MaxNodes: 1}, true},
+		{"cancelled context", cancelled, Limits{}, false},
+	} {
+		puzzle := stalledBoard(t)
+		res := SolveContext(tc.ctx, puzzle, tc.limits)
+		if res.Solved || !res.Interrupted || !errors.Is(res.Reason, ErrInterrupted) {
+			t.Errorf("%s: solved %v, interrupted %v, reason %v", tc.name, res.Solved, res.Interrupted, res.Reason)
+		}
+		if res.Board == nil || res.Board.PuzzleString() != puzzle.PuzzleString() {
+			t.Fatalf("%s: no partial board", tc.name)
+		}
+		bridges := 0
+		for _, r := range res.Board.AllRivers {
+			bridges += r.Bridges
+		}
+		if (bridges > 0) != tc.moved {
+			t.Errorf("%s: the partial board has %d bridges", tc.name, bridges)
+		}
+		if m, err := res.Board.HasMistakes(); m {
+			t.Errorf("%s: the partial board has a mistake: %v", tc.name, err)
+		}
+	}
+}
+
+func TestCountSolutionsInterrupted(t *testing.T) {
+	b := stalledBoard(t)
+	if ct, _, err := b.CountSolutionsContext(context.Background(), 0, Limits{}); ct != 1 || err != nil {
+		t.Fatalf("counted %d solutions, %v; want 1", ct, err)
+	}
+	if _, _, err := b.CountSolutionsContext(context.Background(), 0, Limits{MaxNodes: 1}); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("node limit: got error %v", err)
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := b.CountSolutionsContext(cancelled, 0, Limits{}); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("cancelled context: got error %v", err)
+	}
+}
+
+func TestSearchInPlaceReports(t *testing.T) {
+	b := stalledBoard(t)
+	b.setBudget(context.Background(), Limits{MaxNodes: 1})
+	if b.searchInPlace() {
+		t.Errorf("an interrupted search reported a solution")
+	}
+	b.budget = nil
+	if !b.searchInPlace() {
+		t.Errorf("the search reported no solution")
+	}
+}
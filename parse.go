@@ -0,0 +1,133 @@
+package hashi
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+func BoardFromString(data string) (*Board, error) {
+	lines := make([]string, 0)
+	for _, txt := range strings.Split(data, "\n") {
+		txt = strings.Trim(txt, "\r\n")
+		if len(txt) > 0 {
+			lines = append(lines, txt)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("board has no rows")
+	}
+	b := Board{Grid: make([][]*Island, 0), Rows: len(lines), Cols: len(lines[0]), MaxBridges: 2, Clusters: []*Cluster{}, AllRivers: []*River{}, AllIslands: []*Island{}}
+	for ri, rowstr := range lines {
+		if len(rowstr) != b.Cols {
+			return nil, fmt.Errorf("board has %d cols, but row %d has %d cells", b.Cols, ri, len(rowstr))
+		}
+		row := make([]*Island, b.Cols)
+		b.Grid = append(b.Grid, row)
+		for ci, ch := range rowstr {
+			if ch >= '1' && ch <= '8' {
+				b.AddIsland(int(ch-'0'), ri, ci)
+			}
+		}
+	}
+	b.CreateRivers()
+	return &b, nil
+}
+
+func GetBoardFromFile(fn string) (*Board, error) {
+	start := time.Now()
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	b, err := Parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+	b.stats.Phases = map[string]time.Duration{"Load board": time.Since(start)}
+	return b, nil
+}
+
+// Diagnostic is a problem with a text grid, at a line and column counted
+// from 1.
+type Diagnostic struct {
+	Line    int
+	Col     int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("line %d, column %d: %s", d.Line, d.Col, d.Message)
+}
+
+// ParseError is the error ParseStrict returns for a grid with diagnostics.
+type ParseError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *ParseError) Error() string {
+	if len(e.Diagnostics) == 1 {
+		return e.Diagnostics[0].String()
+	}
+	return fmt.Sprintf("%s (and %d more problems)", e.Diagnostics[0], len(e.Diagnostics)-1)
+}
+
+// LintGrid reports everything in a text grid that BoardFromString would
+// quietly paper over: each character that is neither an island (1-8) nor
+// water ('.' or ' '), each row whose length differs from the first row's,
+// each blank line between rows, and input with no rows at all.
+func LintGrid(data string) []Diagnostic {
+	diags := []Diagnostic{}
+	lines := strings.Split(data, "\n")
+	first, last := -1, -1
+	for ln, txt := range lines {
+		lines[ln] = strings.TrimRight(txt, "\r")
+		if len(lines[ln]) > 0 {
+			if first < 0 {
+				first = ln
+			}
+			last = ln
+		}
+	}
+	if first < 0 {
+		return append(diags, Diagnostic{1, 1, "board has no rows"})
+	}
+	width := len([]rune(lines[first]))
+	for ln := first; ln <= last; ln++ {
+		cells := []rune(lines[ln])
+		if len(cells) == 0 {
+			diags = append(diags, Diagnostic{ln + 1, 1, "blank line inside the grid; an all-water row is written with '.'"})
+			continue
+		}
+		for ci, ch := range cells {
+			msg := ""
+			switch {
+			case (ch >= '1' && ch <= '8') || ch == '.' || ch == ' ':
+				continue
+			case ch == '0' || ch == '9':
+				msg = fmt.Sprintf("%q is not a valid island; islands need 1-8 bridges", ch)
+			case ch == '\t':
+				msg = "tab character; use '.' or ' ' for water"
+			default:
+				msg = fmt.Sprintf("unexpected %q; islands are 1-8 and water is '.' or ' '", ch)
+			}
+			diags = append(diags, Diagnostic{ln + 1, ci + 1, msg})
+		}
+		if len(cells) != width {
+			diags = append(diags, Diagnostic{ln + 1, min(len(cells), width) + 1, fmt.Sprintf("row has %d cells, but the first row has %d", len(cells), width)})
+		}
+	}
+	return diags
+}
+
+// ParseStrict is Parse, except that a text grid with any diagnostics from
+// LintGrid is rejected with a *ParseError that lists them all.
+func ParseStrict(data string) (*Board, error) {
+	if !strings.HasPrefix(strings.TrimSpace(data), "{") {
+		if diags := LintGrid(data); len(diags) > 0 {
+			return nil, &ParseError{diags}
+		}
+	}
+	return Parse(data)
+}
@@ -0,0 +1,49 @@
+package hashi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLintGrid(t *testing.T) {
+	if diags := LintGrid("2.2\n   \n2 2\r\n\n"); len(diags) > 0 {
+		t.Errorf("clean grid: %v", diags)
+	}
+	if diags := LintGrid("\n\n"); len(diags) != 1 || diags[0].Line != 1 {
+		t.Errorf("empty grid: %v", diags)
+	}
+	got := LintGrid("\n2.x\n\n1.10\n\t")
+	want := [][2]int{{2, 3}, {3, 1}, {4, 4}, {4, 4}, {5, 1}, {5, 2}}
+	pos := [][2]int{}
+	for _, d := range got {
+		pos = append(pos, [2]int{d.Line, d.Col})
+	}
+	if !reflect.DeepEqual(pos, want) {
+		t.Errorf("diagnostics at %v, want %v: %v", pos, want, got)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	if _, err := ParseStrict("2.2\n...\n2.2\n"); err != nil {
+		t.Errorf("clean grid: %v", err)
+	}
+	_, err := ParseStrict("2.2\n.x.\n2.2\n")
+	var perr *ParseError
+	if !errors.As(err, &perr) || len(perr.Diagnostics) != 1 || perr.Diagnostics[0].Line != 2 || perr.Diagnostics[0].Col != 2 {
+		t.Errorf("got error %v, want one diagnostic at line 2, column 2", err)
+	}
+	if _, err := Parse("2.2\n.x.\n2.2\n"); err != nil {
+		t.Errorf("Parse refused a stray character: %v", err)
+	}
+	entries, err := ParseCollection("# id: a\n2.2\n\n# id: b\n2.x\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := entries[1].Lint(); len(diags) != 1 || diags[0].Line != 5 || diags[0].Col != 3 {
+		t.Errorf("entry diagnostics %v, want one at line 5, column 3", diags)
+	}
+	if _, err := entries[1].BoardStrict(); err == nil {
+		t.Errorf("BoardStrict accepted a stray character")
+	}
+}
@@ -0,0 +1,241 @@
+package hashi
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+// PNGOptions configures Board.Image and Board.PNG.
+type PNGOptions struct {
+	Mode DrawMode
+	//CellSize is the width and height of a grid cell in pixels; 0 means 40
+	CellSize int
+	//Highlight is a river of b to pick out in blue, or in dashed orange if
+	//it has no bridges
+	Highlight *River
+}
+
+// font is a 5x7 bitmap of each character Image and ReplayGIF write, one
+// row per byte with the leftmost pixel in the 0x10 bit. Characters it does
+// not have are drawn as spaces.
+var font = map[rune][7]byte{
+	' ': {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	'0': {0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e},
+	'1': {0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'2': {0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f},
+	'3': {0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e},
+	'4': {0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02},
+	'5': {0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e},
+	'6': {0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e},
+	'7': {0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	'8': {0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e},
+	'9': {0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c},
+	'A': {0x0e, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x11},
+	'B': {0x1e, 0x11, 0x11, 0x1e, 0x11, 0x11, 0x1e},
+	'C': {0x0e, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0e},
+	'D': {0x1c, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1c},
+	'E': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x1f},
+	'F': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x10},
+	'G': {0x0e, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0f},
+	'H': {0x11, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x11},
+	'I': {0x0e, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'J': {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0c},
+	'K': {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11},
+	'L': {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1f},
+	'M': {0x11, 0x1b, 0x15, 0x15, 0x11, 0x11, 0x11},
+	'N': {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11},
+	'O': {0x0e, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e},
+	'P': {0x1e, 0x11, 0x11, 0x1e, 0x10, 0x10, 0x10},
+	'Q': {0x0e, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0d},
+	'R': {0x1e, 0x11, 0x11, 0x1e, 0x14, 0x12, 0x11},
+	'S': {0x0f, 0x10, 0x10, 0x0e, 0x01, 0x01, 0x1e},
+	'T': {0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'U': {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e},
+	'V': {0x11, 0x11, 0x11, 0x11, 0x11, 0x0a, 0x04},
+	'W': {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0a},
+	'X': {0x11, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x11},
+	'Y': {0x11, 0x11, 0x0a, 0x04, 0x04, 0x04, 0x04},
+	'Z': {0x1f, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1f},
+	'a': {0x00, 0x00, 0x0e, 0x01, 0x0f, 0x11, 0x0f},
+	'b': {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x1e},
+	'c': {0x00, 0x00, 0x0e, 0x10, 0x10, 0x11, 0x0e},
+	'd': {0x01, 0x01, 0x0d, 0x13, 0x11, 0x11, 0x0f},
+	'e': {0x00, 0x00, 0x0e, 0x11, 0x1f, 0x10, 0x0e},
+	'f': {0x06, 0x09, 0x08, 0x1c, 0x08, 0x08, 0x08},
+	'g': {0x00, 0x0f, 0x11, 0x11, 0x0f, 0x01, 0x0e},
+	'h': {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x11},
+	'i': {0x04, 0x00, 0x0c, 0x04, 0x04, 0x04, 0x0e},
+	'j': {0x02, 0x00, 0x06, 0x02, 0x02, 0x12, 0x0c},
+	'k': {0x10, 0x10, 0x12, 0x14, 0x18, 0x14, 0x12},
+	'l': {0x0c, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e},
+	'm': {0x00, 0x00, 0x1a, 0x15, 0x15, 0x11, 0x11},
+	'n': {0x00, 0x00, 0x16, 0x19, 0x11, 0x11, 0x11},
+	'o': {0x00, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e},
+	'p': {0x00, 0x00, 0x1e, 0x11, 0x1e, 0x10, 0x10},
+	'q': {0x00, 0x00, 0x0d, 0x13, 0x0f, 0x01, 0x01},
+	'r': {0x00, 0x00, 0x16, 0x19, 0x10, 0x10, 0x10},
+	's': {0x00, 0x00, 0x0e, 0x10, 0x0e, 0x01, 0x1e},
+	't': {0x08, 0x08, 0x1c, 0x08, 0x08, 0x09, 0x06},
+	'u': {0x00, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0d},
+	'v': {0x00, 0x00, 0x11, 0x11, 0x11, 0x0a, 0x04},
+	'w': {0x00, 0x00, 0x11, 0x11, 0x15, 0x15, 0x0a},
+	'x': {0x00, 0x00, 0x11, 0x0a, 0x04, 0x0a, 0x11},
+	'y': {0x00, 0x00, 0x11, 0x11, 0x0f, 0x01, 0x0e},
+	'z': {0x00, 0x00, 0x1f, 0x02, 0x04, 0x08, 0x1f},
+	'(': {0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02},
+	')': {0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08},
+	',': {0x00, 0x00, 0x00, 0x00, 0x0c, 0x04, 0x08},
+	'-': {0x00, 0x00, 0x00, 0x1f, 0x00, 0x00, 0x00},
+	'.': {0x00, 0x00, 0x00, 0x00, 0x00, 0x0c, 0x0c},
+	'/': {0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00},
+	':': {0x00, 0x0c, 0x0c, 0x00, 0x0c, 0x0c, 0x00},
+	'=': {0x00, 0x00, 0x1f, 0x00, 0x1f, 0x00, 0x00},
+}
+
+var (
+	inkColor       = color.RGBA{0, 0, 0, 255}
+	paperColor     = color.RGBA{255, 255, 255, 255}
+	faintColor     = color.RGBA{187, 187, 187, 255}
+	errorColor     = color.RGBA{255, 0, 0, 255}
+	errorFill      = color.RGBA{255, 204, 204, 255}
+	highlightColor = color.RGBA{0, 102, 255, 255}
+	capColor       = color.RGBA{255, 136, 0, 255}
+)
+
+// Image draws b the way SVG does, as a raster image.
+func (b *Board) Image(opts PNGOptions) *image.RGBA {
+	cs := opts.CellSize
+	if cs <= 0 {
+		cs = 40
+	}
+	img := image.NewRGBA(image.Rect(0, 0, b.Cols*cs, b.Rows*cs))
+	draw.Draw(img, img.Bounds(), image.NewUniform(paperColor), image.Point{}, draw.Src)
+	center := func(i *Island) image.Point {
+		return image.Pt(i.C*cs+cs/2, i.R*cs+cs/2)
+	}
+	thick := max(1, cs/20)
+	badIslands, badRivers := map[*Island]bool{}, map[*River]bool{}
+	if opts.Mode == DrawMistakes {
+		badIslands, badRivers = b.mistakenParts()
+	}
+
+	if opts.Mode != DrawPuzzle {
+		for _, r := range b.AllRivers {
+			p1, p2 := center(r.Islands[0]), center(r.Islands[1])
+			ink := inkColor
+			if badRivers[r] {
+				ink = errorColor
+				if r.Bridges == 0 {
+					fillDashes(img, p1, p2, 0, thick, cs/10, errorColor)
+				}
+			}
+			if opts.Mode == DrawState && r.ToGive > 0 {
+				fillDashes(img, p1, p2, 0, max(1, thick/2), cs/10, faintColor)
+			}
+			if r == opts.Highlight {
+				ink = highlightColor
+				if r.Bridges == 0 {
+					fillDashes(img, p1, p2, 0, thick, cs/10, capColor)
+				}
+			}
+			//parallel bridges sit side by side, centered on the line
+			//between the islands
+			gap := cs / 8
+			for k := 0; k < r.Bridges; k++ {
+				off := k*gap - (r.Bridges-1)*gap/2
+				fillDashes(img, p1, p2, off, thick, 0, ink)
+			}
+		}
+	}
+
+	radius := cs * 2 / 5
+	scale := max(1, cs/20)
+	for _, i := range b.AllIslands {
+		fill, ring := paperColor, inkColor
+		if badIslands[i] {
+			fill, ring = errorFill, errorColor
+		}
+		c := center(i)
+		fillCircle(img, c, radius, ring)
+		fillCircle(img, c, radius-thick, fill)
+		drawNumber(img, c, i.Num, scale, ring)
+	}
+	return img
+}
+
+// PNG writes b to w as a PNG image drawn by Image.
+func (b *Board) PNG(w io.Writer, opts PNGOptions) error {
+	return png.Encode(w, b.Image(opts))
+}
+
+// fillDashes draws a horizontal or vertical line from p1 to p2, shifted
+// sideways by off and thick pixels wide. If dash is more than 0 the line
+// is broken into dashes of that length.
+func fillDashes(img *image.RGBA, p1 image.Point, p2 image.Point, off int, thick int, dash int, c color.Color) {
+	if p1.X > p2.X || p1.Y > p2.Y {
+		p1, p2 = p2, p1
+	}
+	horizontal := p1.Y == p2.Y
+	length := p2.X - p1.X
+	if !horizontal {
+		length = p2.Y - p1.Y
+	}
+	for start := 0; start < length; start += 2 * dash {
+		end := length
+		if dash > 0 {
+			end = min(length, start+dash)
+		}
+		rect := image.Rect(p1.X+start, p1.Y+off-thick/2, p1.X+end, p1.Y+off-thick/2+thick)
+		if !horizontal {
+			rect = image.Rect(p1.X+off-thick/2, p1.Y+start, p1.X+off-thick/2+thick, p1.Y+end)
+		}
+		draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+		if dash <= 0 {
+			break
+		}
+	}
+}
+
+func fillCircle(img *image.RGBA, c image.Point, radius int, col color.Color) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				img.Set(c.X+x, c.Y+y, col)
+			}
+		}
+	}
+}
+
+// drawNumber writes num centered on c, each font pixel scale pixels
+// across.
+func drawNumber(img draw.Image, c image.Point, num int, scale int, col color.Color) {
+	digits := strconv.Itoa(num)
+	drawText(img, image.Pt(c.X-textWidth(digits, scale)/2, c.Y-7*scale/2), digits, scale, col)
+}
+
+// textWidth is how many pixels wide drawText draws text.
+func textWidth(text string, scale int) int {
+	//glyphs are 5 wide with a 1 pixel gap between them
+	return (len([]rune(text))*6 - 1) * scale
+}
+
+// drawText writes text in font with its top left corner at p, each font
+// pixel scale pixels across.
+func drawText(img draw.Image, p image.Point, text string, scale int, col color.Color) {
+	for idx, ch := range []rune(text) {
+		glyph := font[ch]
+		for row, bits := range glyph {
+			for bit := 0; bit < 5; bit++ {
+				if bits&(0x10>>bit) == 0 {
+					continue
+				}
+				x, y := p.X+(idx*6+bit)*scale, p.Y+row*scale
+				draw.Draw(img, image.Rect(x, y, x+scale, y+scale), image.NewUniform(col), image.Point{}, draw.Src)
+			}
+		}
+	}
+}
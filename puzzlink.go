@@ -0,0 +1,142 @@
+package hashi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BoardFromPuzzLink reads a hashikake URL from puzz.link or another pzprjs
+// site, such as "https://puzz.link/p?hashikake/7/7/1g3i2...". Everything
+// before the '?' is ignored, so the bare "hashikake/7/7/..." works too. The
+// path gives the number of columns, the number of rows and then the clues
+// in pzprjs's number16 encoding: a hex digit per island, - or + before
+// longer numbers, '.' for an island whose clue is unknown, and g through z
+// for runs of 1 through 20 empty cells.
+//
+// The solver needs every clue, so a puzzle with unknown clues is rejected
+// with an error that lists them.
+func BoardFromPuzzLink(url string) (*Board, error) {
+	url = strings.TrimSpace(url)
+	if q := strings.Index(url, "?"); q >= 0 {
+		url = url[q+1:]
+	}
+	parts := strings.Split(url, "/")
+	if parts[0] != "hashikake" {
+		return nil, fmt.Errorf("URL is for %q, not hashikake", parts[0])
+	}
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("URL has no size and clues")
+	}
+	cols, errc := strconv.Atoi(parts[1])
+	rows, errr := strconv.Atoi(parts[2])
+	if errc != nil || errr != nil || cols < 1 || rows < 1 {
+		return nil, fmt.Errorf("URL has no valid size: %q by %q", parts[1], parts[2])
+	}
+	clues, err := decodeNumber16(parts[3], rows*cols)
+	if err != nil {
+		return nil, err
+	}
+	b := Board{Grid: make([][]*Island, rows), Rows: rows, Cols: cols, MaxBridges: 2, Clusters: []*Cluster{}, AllRivers: []*River{}, AllIslands: []*Island{}}
+	for ri := range b.Grid {
+		b.Grid[ri] = make([]*Island, cols)
+	}
+	unknown := []string{}
+	for cell, num := range clues {
+		ri, ci := cell/cols, cell%cols
+		switch {
+		case num == 0:
+			continue
+		case num == unknownClue:
+			unknown = append(unknown, fmt.Sprintf("(r%d, c%d)", ri, ci))
+		case num > 8:
+			return nil, fmt.Errorf("island at (r%d, c%d) needs %d bridges; at most 8 are possible", ri, ci, num)
+		default:
+			b.AddIsland(num, ri, ci)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown clues are not supported: %s", strings.Join(unknown, ", "))
+	}
+	b.CreateRivers()
+	return &b, nil
+}
+
+// unknownClue marks a '?' island in decodeNumber16's output.
+const unknownClue = -1
+
+// decodeNumber16 reads n cells of pzprjs's number16 encoding, returning 0
+// for each empty cell and unknownClue for each '?'. Cells the encoding
+// stops short of are empty.
+func decodeNumber16(body string, n int) ([]int, error) {
+	clues := make([]int, n)
+	cell := 0
+	for idx := 0; idx < len(body) && cell < n; idx++ {
+		ch := body[idx]
+		switch {
+		case (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f'):
+			num, _ := strconv.ParseInt(string(ch), 16, 0)
+			clues[cell] = int(num)
+		case ch == '-' || ch == '+':
+			width := 2
+			if ch == '+' {
+				width = 3
+			}
+			if idx+width >= len(body) {
+				return nil, fmt.Errorf("number at position %d is cut off", idx)
+			}
+			num, err := strconv.ParseInt(body[idx+1:idx+1+width], 16, 0)
+			if err != nil {
+				return nil, fmt.Errorf("bad number %q at position %d", body[idx+1:idx+1+width], idx)
+			}
+			clues[cell] = int(num)
+			idx += width
+		case ch == '.':
+			clues[cell] = unknownClue
+		case ch >= 'g' && ch <= 'z':
+			cell += int(ch - 'f')
+			continue
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d of the clues", ch, idx)
+		}
+		cell++
+	}
+	if cell > n {
+		return nil, fmt.Errorf("clues describe %d cells; the board has %d", cell, n)
+	}
+	return clues, nil
+}
+
+// PuzzLinkURL encodes the puzzle, without any bridges, as a puzz.link
+// hashikake URL that BoardFromPuzzLink reads. Only boards that allow 2
+// bridges per river can be encoded, since that is the only kind hashikake
+// has.
+func (b *Board) PuzzLinkURL() (string, error) {
+	if b.MaxBridges != 2 {
+		return "", fmt.Errorf("hashikake always allows 2 bridges per river, but this board allows %d", b.MaxBridges)
+	}
+	body := strings.Builder{}
+	run := 0
+	for ri := 0; ri < b.Rows; ri++ {
+		for ci := 0; ci < b.Cols; ci++ {
+			i := b.Grid[ri][ci]
+			if i == nil {
+				run++
+				if run == 20 {
+					body.WriteByte('z')
+					run = 0
+				}
+				continue
+			}
+			if run > 0 {
+				body.WriteByte(byte('f' + run))
+				run = 0
+			}
+			body.WriteString(strconv.FormatInt(int64(i.Num), 16))
+		}
+	}
+	if run > 0 {
+		body.WriteByte(byte('f' + run))
+	}
+	return fmt.Sprintf("https://puzz.link/p?hashikake/%d/%d/%s", b.Cols, b.Rows, body.String()), nil
+}
@@ -0,0 +1,54 @@
+package hashi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPuzzLinkRoundTrip(t *testing.T) {
+	url := "https://puzz.link/p?hashikake/3/3/2g2i2g2"
+	b, err := BoardFromPuzzLink(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.PuzzleString() != mustParse(t, "2.2\n...\n2.2\n").PuzzleString() {
+		t.Errorf("read as\n%s", b.PuzzleString())
+	}
+	if again, _ := b.PuzzLinkURL(); again != url {
+		t.Errorf("URL %q came back as %q", url, again)
+	}
+}
+
+func TestPuzzLinkEncoding(t *testing.T) {
+	//a run of 22 empty cells takes z for 20 and h for 2, a number may be
+	//written with - and two hex digits, and clues can stop short
+	b, err := BoardFromPuzzLink("hashikake/25/1/1zh-03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.AllIslands) != 2 || b.Grid[0][0].Num != 1 || b.Grid[0][23] == nil || b.Grid[0][23].Num != 3 {
+		t.Fatalf("read as\n%s", b.PuzzleString())
+	}
+	if url, _ := b.PuzzLinkURL(); url != "https://puzz.link/p?hashikake/25/1/1zh3g" {
+		t.Errorf("PuzzLinkURL: %q", url)
+	}
+}
+
+func TestPuzzLinkErrors(t *testing.T) {
+	_, err := BoardFromPuzzLink("hashikake/3/3/2g.i1g1")
+	if err == nil || !strings.Contains(err.Error(), "(r0, c2)") {
+		t.Errorf("unknown clue: got error %v, want one naming (r0, c2)", err)
+	}
+	for _, bad := range []string{"hashikake/3/3", "hashikake/x/3/2g2i2g2", "nurikabe/3/3/2g2i2g2", "hashikake/3/3/9z", "hashikake/3/1/1-0"} {
+		if _, err := BoardFromPuzzLink(bad); err == nil {
+			t.Errorf("%q: no error", bad)
+		}
+	}
+	b, err := BoardFromTatham("3x1m1:1a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.PuzzLinkURL(); err == nil {
+		t.Errorf("encoded a board that allows 1 bridge per river")
+	}
+}
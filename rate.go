@@ -0,0 +1,86 @@
+package hashi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Technique is a solving technique the rater knows, ranked by Level from
+// easiest to hardest. Weight is what each use of it adds to a Rating's Score.
+type Technique struct {
+	Rule   string
+	Level  int
+	Weight int
+}
+
+// Techniques lists the techniques the rater tries, easiest first. The
+// search is not a rule, but it is what a solve falls back to when every
+// rule is stuck, so it ranks last.
+var Techniques = []Technique{
+	{RuleRequiredFill, 1, 1},
+	{RuleJoinedIsolation, 2, 3},
+	{RuleSelfIsolation, 2, 3},
+	{RuleBadCorners, 3, 6},
+	{RuleMakeAGuess, 4, 15},
+	{RuleSearch, 5, 50},
+}
+
+// Rating describes how hard a puzzle is to solve.
+type Rating struct {
+	Solved bool
+	// Hardest is the hardest technique the solve needed, and Level its
+	// level; Level is 0 if the puzzle needed no moves at all.
+	Hardest string
+	Level   int
+	// Uses counts the moves each technique made.
+	Uses map[string]int
+	// Score sums the weights of all of the moves.
+	Score int
+}
+
+func (r *Rating) String() string {
+	out := fmt.Sprintf("score %d, hardest %s (level %d), uses:", r.Score, r.Hardest, r.Level)
+	names := []string{}
+	for name := range r.Uses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		out += fmt.Sprintf(" %s=%d", name, r.Uses[name])
+	}
+	if !r.Solved {
+		out += " (not solved)"
+	}
+	return out
+}
+
+// Rate solves a copy of the puzzle with the rated techniques, always using
+// the easiest one that makes progress, and rates it by what the solve
+// needed.
+func Rate(puzzle *Board) (*Rating, error) {
+	b := puzzle.clone()
+	rules := []string{}
+	for _, t := range Techniques {
+		if t.Rule != RuleSearch {
+			rules = append(rules, t.Rule)
+		}
+	}
+	var err error
+	if b.Rules, err = RulesByName(rules); err != nil {
+		return nil, err
+	}
+	res := Solve(b)
+	rating := &Rating{Solved: res.Solved, Uses: res.Stats.Moves}
+	for _, t := range Techniques {
+		ct := rating.Uses[t.Rule]
+		if ct == 0 {
+			continue
+		}
+		rating.Score += ct * t.Weight
+		if t.Level > rating.Level {
+			rating.Level = t.Level
+			rating.Hardest = t.Rule
+		}
+	}
+	return rating, nil
+}
@@ -0,0 +1,53 @@
+package hashi
+
+import "testing"
+
+func TestRateOrders(t *testing.T) {
+	easy := mustParse(t, "1.2.1\n")
+	//needs MakeAGuess once the other rules stall
+	hard := mustParse(t, `2.1..3.4.4
+.2.5....3.
+5.1.1.2...
+........2.
+5..5..5..4
+..1..2....
+.......2.4
+3..2.3....
+..........
+1.2.3.5..2
+`)
+	easyRating, err := Rate(easy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hardRating, err := Rate(hard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !easyRating.Solved || !hardRating.Solved {
+		t.Fatalf("not solved: %s; %s", easyRating, hardRating)
+	}
+	if easyRating.Level != 1 || easyRating.Hardest != RuleRequiredFill {
+		t.Errorf("easy puzzle: %s", easyRating)
+	}
+	if hardRating.Level < 4 || hardRating.Uses[RuleMakeAGuess] == 0 {
+		t.Errorf("hard puzzle: %s", hardRating)
+	}
+	if hardRating.Score <= easyRating.Score {
+		t.Errorf("hard puzzle scored %d, easy one %d", hardRating.Score, easyRating.Score)
+	}
+}
+
+func TestRateLeavesPuzzle(t *testing.T) {
+	b := mustParse(t, "2.2\n...\n2.2\n")
+	before := snapshot(b)
+	if _, err := Rate(b); err != nil {
+		t.Fatal(err)
+	}
+	if got := snapshot(b); got != before {
+		t.Errorf("Rate changed the board")
+	}
+	if s := b.Stats(); s.Clones != 0 || len(s.Phases) != 0 {
+		t.Errorf("Rate counted %d clones and %d phases on the puzzle", s.Clones, len(s.Phases))
+	}
+}
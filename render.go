@@ -0,0 +1,275 @@
+package hashi
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (b *Board) String() string {
+	return b.String2(true)
+}
+
+// bridgeCell is how many bridges run across a water cell in each
+// direction.
+type bridgeCell struct {
+	h int
+	v int
+}
+
+// bridgeCells returns the bridges running across each cell of b.
+func (b *Board) bridgeCells() [][]bridgeCell {
+	cells := make([][]bridgeCell, b.Rows)
+	for ri := range cells {
+		cells[ri] = make([]bridgeCell, b.Cols)
+	}
+	for _, r := range b.AllRivers {
+		if r.Bridges == 0 {
+			continue
+		}
+
+		if r.Islands[0].R == r.Islands[1].R {
+			//Horizontal
+			cleft := min(r.Islands[0].C, r.Islands[1].C)
+			cright := max(r.Islands[0].C, r.Islands[1].C)
+			ri := r.Islands[0].R
+			for ci := cleft + 1; ci < cright; ci++ {
+				Trace("Writing %d horizontal bridges at %d, %d\n", r.Bridges, ri, ci)
+				cells[ri][ci].h = r.Bridges
+			}
+		} else {
+			//Vertical
+			rtop := min(r.Islands[0].R, r.Islands[1].R)
+			rbot := max(r.Islands[0].R, r.Islands[1].R)
+			ci := r.Islands[0].C
+			for ri := rtop + 1; ri < rbot; ri++ {
+				Trace("Writing %d vertical bridges at %d, %d\n", r.Bridges, ri, ci)
+				cells[ri][ci].v = r.Bridges
+			}
+		}
+	}
+	return cells
+}
+
+// drawGrid draws b one rune per cell, using island to draw each island and
+// symbols[h][v] to draw a water cell crossed by h horizontal and v vertical
+// bridges. Counts the table has no room for are left out.
+func (b *Board) drawGrid(island func(i *Island) rune, symbols [3][3]rune) [][]rune {
+	cells := b.bridgeCells()
+	grid := make([][]rune, b.Rows)
+	for ri := 0; ri < b.Rows; ri++ {
+		grid[ri] = make([]rune, b.Cols)
+		for ci := 0; ci < b.Cols; ci++ {
+			if b.Grid[ri][ci] != nil {
+				grid[ri][ci] = island(b.Grid[ri][ci])
+				continue
+			}
+			h, v := cells[ri][ci].h, cells[ri][ci].v
+			if h > 2 {
+				h = 0
+			}
+			if v > 2 {
+				v = 0
+			}
+			grid[ri][ci] = symbols[h][v]
+		}
+	}
+	return grid
+}
+
+// asciiBridges are the bridge symbols String2 draws, indexed by the number
+// of horizontal and then vertical bridges.
+var asciiBridges = [3][3]rune{
+	{' ', '|', '"'},
+	{'-', '+', 'H'},
+	{'=', 'F', '#'},
+}
+
+// DrawMode says how much of a board a picture shows.
+type DrawMode int
+
+const (
+	//DrawPuzzle shows only the islands and their clues
+	DrawPuzzle DrawMode = iota
+	//DrawSolution adds the bridges
+	DrawSolution
+	//DrawState adds the bridges and, faintly, the rivers that can still
+	//take more
+	DrawState
+	//DrawMistakes adds the bridges and highlights the islands and rivers
+	//involved in each of b.Mistakes()
+	DrawMistakes
+)
+
+// ParseDrawMode reads a DrawMode by name: puzzle, solution, state or
+// mistakes.
+func ParseDrawMode(name string) (DrawMode, error) {
+	switch name {
+	case "puzzle":
+		return DrawPuzzle, nil
+	case "solution":
+		return DrawSolution, nil
+	case "state":
+		return DrawState, nil
+	case "mistakes":
+		return DrawMistakes, nil
+	}
+	return 0, fmt.Errorf("unknown mode %q; use puzzle, solution, state or mistakes", name)
+}
+
+// mistakenParts returns the islands and rivers involved in b's mistakes.
+func (b *Board) mistakenParts() (map[*Island]bool, map[*River]bool) {
+	islands, rivers := map[*Island]bool{}, map[*River]bool{}
+	for _, v := range b.Mistakes() {
+		for _, i := range v.Islands {
+			islands[i] = true
+		}
+		for _, r := range v.Rivers {
+			rivers[r] = true
+		}
+	}
+	return islands, rivers
+}
+
+func digitIsland(i *Island) rune {
+	return rune(fmt.Sprintf("%d", i.Num)[0])
+}
+
+func circledIsland(i *Island) rune {
+	return '①' + rune(i.Num-1)
+}
+
+func (b *Board) String2(short bool) string {
+	grid := b.drawGrid(digitIsland, asciiBridges)
+	out := ""
+	for _, runerow := range grid {
+		for _, r := range runerow {
+			out += string(r)
+		}
+		out += "\n"
+	}
+	if short {
+		return out[:len(out)-1]
+	}
+	out += fmt.Sprintf("Clusters (%d)\n", len(b.Clusters))
+	for _, c := range b.Clusters {
+		out += fmt.Sprintf("%s\n", c)
+	}
+	return out[:len(out)-1]
+}
+
+// PuzzleString renders the puzzle's clues, without bridges, in the text
+// format BoardFromString reads: a digit for each island and '.' for water.
+func (b *Board) PuzzleString() string {
+	lines := make([]string, b.Rows)
+	for ri := 0; ri < b.Rows; ri++ {
+		row := make([]byte, b.Cols)
+		for ci := 0; ci < b.Cols; ci++ {
+			row[ci] = '.'
+			if b.Grid[ri][ci] != nil {
+				row[ci] = byte('0' + b.Grid[ri][ci].Num)
+			}
+		}
+		lines[ri] = string(row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// unicodeBridges are UnicodeString's counterparts to asciiBridges.
+var unicodeBridges = [3][3]rune{
+	{' ', '│', '║'},
+	{'─', '┼', '╫'},
+	{'═', '╪', '╬'},
+}
+
+// UnicodeString draws b the way String does, but with box-drawing
+// characters for the bridges and circled numbers for the islands, so that
+// double bridges and crossings cannot be mistaken for one another or for
+// the clues.
+func (b *Board) UnicodeString() string {
+	grid := b.drawGrid(circledIsland, unicodeBridges)
+	lines := make([]string, b.Rows)
+	for ri, row := range grid {
+		lines[ri] = string(row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// clusterColors are the ANSI foreground colors ColorString gives clusters,
+// in turn.
+var clusterColors = []string{"36", "33", "35", "32", "34", "31", "96", "93", "95", "92", "94", "91"}
+
+// ColorString draws b with ANSI color codes for a terminal. Each cluster's
+// islands and bridges get their own color, islands that still need bridges
+// are shown in reverse video, and the cells of empty rivers whose ToGive
+// has been capped to 0 are dotted. With unicode set it draws the way
+// UnicodeString does, and otherwise the way String does.
+func (b *Board) ColorString(unicode bool) string {
+	island, symbols := digitIsland, asciiBridges
+	if unicode {
+		island, symbols = circledIsland, unicodeBridges
+	}
+	grid := b.drawGrid(island, symbols)
+
+	clusterColor := map[int]string{}
+	for idx, c := range b.Clusters {
+		clusterColor[c.rootIndex()] = clusterColors[idx%len(clusterColors)]
+	}
+	colorOf := func(i *Island) string {
+		return clusterColor[i.Cluster().rootIndex()]
+	}
+	colors := make([][]string, b.Rows)
+	for ri := range colors {
+		colors[ri] = make([]string, b.Cols)
+	}
+	for _, i := range b.AllIslands {
+		colors[i.R][i.C] = "1;" + colorOf(i)
+		if !i.IsComplete() {
+			colors[i.R][i.C] = "1;7;" + colorOf(i)
+		}
+	}
+	for _, r := range b.AllRivers {
+		if r.Bridges == 0 && r.ToGive > 0 {
+			continue
+		}
+		for _, cell := range riverCells(r) {
+			ri, ci := cell[0], cell[1]
+			switch {
+			case r.Bridges > 0:
+				colors[ri][ci] = colorOf(r.Islands[0])
+			case grid[ri][ci] == ' ':
+				grid[ri][ci] = '·'
+				colors[ri][ci] = "2"
+			}
+		}
+	}
+
+	lines := make([]string, b.Rows)
+	for ri, row := range grid {
+		line := strings.Builder{}
+		for ci, ch := range row {
+			if colors[ri][ci] == "" {
+				line.WriteRune(ch)
+				continue
+			}
+			fmt.Fprintf(&line, "\x1b[%sm%c\x1b[0m", colors[ri][ci], ch)
+		}
+		lines[ri] = line.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// riverCells returns the water cells r runs across, as row and column pairs.
+func riverCells(r *River) [][2]int {
+	cells := [][2]int{}
+	ia, ib := r.Islands[0], r.Islands[1]
+	if ia.R == ib.R {
+		for ci := min(ia.C, ib.C) + 1; ci < max(ia.C, ib.C); ci++ {
+			cells = append(cells, [2]int{ia.R, ci})
+		}
+	} else {
+		for ri := min(ia.R, ib.R) + 1; ri < max(ia.R, ib.R); ri++ {
+			cells = append(cells, [2]int{ri, ia.C})
+		}
+	}
+	return cells
+}
@@ -0,0 +1,155 @@
+package hashi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/gif"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestUnicodeString(t *testing.T) {
+	for _, tc := range []struct {
+		drawn   string
+		unicode string
+	}{
+		{"1-1", "①─①"},
+		{"2=2", "②═②"},
+		{"1\n|\n1", "①\n│\n①"},
+		{"2\n\"\n2", "②\n║\n②"},
+		{".1.\n1+1\n.1.", " ① \n①┼①\n ① "},
+		{".2.\n1H1\n.2.", " ② \n①╫①\n ② "},
+		{".1.\n2F2\n.1.", " ① \n②╪②\n ① "},
+		{".2.\n2#2\n.2.", " ② \n②╬②\n ② "},
+	} {
+		b, err := BoardFromSolution(tc.drawn)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.drawn, err)
+		}
+		want := strings.ReplaceAll(tc.drawn, ".", " ")
+		if got := b.String2(true); got != want {
+			t.Errorf("String2 drew\n%s\nwant\n%s", got, want)
+		}
+		if got := b.UnicodeString(); got != tc.unicode {
+			t.Errorf("UnicodeString drew\n%s\nwant\n%s", got, tc.unicode)
+		}
+	}
+}
+
+// svgCounts counts the elements of each name in an SVG image and collects
+// the text of its <text> elements, failing unless the image is well formed.
+func svgCounts(t *testing.T, svg string) (map[string]int, []string) {
+	t.Helper()
+	counts := map[string]int{}
+	texts := []string{}
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	inText := false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return counts, texts
+		}
+		if err != nil {
+			t.Fatalf("bad SVG: %v\n%s", err, svg)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			counts[tok.Name.Local]++
+			inText = tok.Name.Local == "text"
+		case xml.CharData:
+			if inText {
+				texts = append(texts, string(tok))
+			}
+		case xml.EndElement:
+			inText = false
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	b, err := BoardFromSolution("2-2\n|.|\n2-2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		mode  DrawMode
+		lines int
+	}{
+		{DrawPuzzle, 0},
+		{DrawSolution, 4},
+	} {
+		svg := b.SVG(SVGOptions{Mode: tc.mode, CellSize: 20})
+		if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="60" height="60"`) {
+			t.Errorf("mode %d: SVG starts %.80q", tc.mode, svg)
+		}
+		counts, texts := svgCounts(t, svg)
+		if counts["circle"] != 4 || counts["line"] != tc.lines {
+			t.Errorf("mode %d: %d circles and %d lines, want 4 and %d", tc.mode, counts["circle"], counts["line"], tc.lines)
+		}
+		if strings.Join(texts, " ") != "2 2 2 2" {
+			t.Errorf("mode %d: clues %q", tc.mode, texts)
+		}
+	}
+}
+
+func TestPNG(t *testing.T) {
+	b, err := BoardFromSolution("2-2\n|.|\n2-2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		mode   DrawMode
+		bridge bool
+	}{
+		{DrawPuzzle, false},
+		{DrawSolution, true},
+	} {
+		buf := bytes.Buffer{}
+		if err := b.PNG(&buf, PNGOptions{Mode: tc.mode, CellSize: 20}); err != nil {
+			t.Fatal(err)
+		}
+		img, err := png.Decode(&buf)
+		if err != nil {
+			t.Fatalf("mode %d: %v", tc.mode, err)
+		}
+		if size := img.Bounds().Size(); size.X != 60 || size.Y != 60 {
+			t.Fatalf("mode %d: %v image, want 60x60", tc.mode, size)
+		}
+		//the middle of the top bridge
+		_, _, blue, _ := img.At(30, 10).RGBA()
+		if drawn := blue == 0; drawn != tc.bridge {
+			t.Errorf("mode %d: bridge drawn %v, want %v", tc.mode, drawn, tc.bridge)
+		}
+	}
+}
+
+func TestReplayGIF(t *testing.T) {
+	b := mustParse(t, "2.2\n...\n2.2\n")
+	b.Explain = true
+	res := Solve(b)
+	if !res.Solved {
+		t.Fatalf("not solved: %v", res.Reason)
+	}
+	buf := bytes.Buffer{}
+	if err := res.Board.ReplayGIF(&buf, GIFOptions{CellSize: 20, Delay: 10}); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the empty puzzle, one frame per move and the finished board
+	if want := len(res.Board.Moves) + 2; len(anim.Image) != want {
+		t.Fatalf("%d frames, want %d", len(anim.Image), want)
+	}
+	for idx, frame := range anim.Image {
+		if size := frame.Bounds().Size(); size.X < 60 || size.Y < 60 {
+			t.Errorf("frame %d is %v, too small for a 60x60 board", idx, size)
+		}
+	}
+	if last := anim.Delay[len(anim.Delay)-1]; anim.Delay[0] != 10 || last != 40 {
+		t.Errorf("delays %d and %d, want 10 and 40", anim.Delay[0], last)
+	}
+}
@@ -0,0 +1,66 @@
+package hashi
+
+import "fmt"
+
+const (
+	HORIZONTAL = 0
+	VERTICAL   = 1
+)
+
+type River struct {
+	Islands   []*Island
+	Crossings []*River
+	Bridges   int
+	ToGive    int
+	Max       int
+	board     *Board
+}
+
+func (r *River) CapToGive(mx int) bool {
+	if r.ToGive > mx {
+		r.SetToGive(mx)
+		return true
+	}
+	return false
+}
+
+func (r *River) SetToGive(ct int) {
+	r.board.setToGive(r, ct)
+	r.Islands[0].Update()
+	r.Islands[1].Update()
+}
+
+func (r *River) Crosses(other *River) bool {
+	for _, test := range r.Crossings {
+		if test == other {
+			return true
+		}
+	}
+	return false
+}
+
+// Is the parameter target one of the two islands that r connects?
+func (r *River) Connects(target *Island) bool {
+	for _, i := range r.Islands {
+		if i == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *River) Neighbor(me *Island) *Island {
+	if r.Islands[0] == me {
+		return r.Islands[1]
+	}
+	return r.Islands[0]
+}
+
+func (r *River) String() string {
+	return fmt.Sprintf("%s <=> %s", r.Islands[0], r.Islands[1])
+}
+
+func markCrossing(a *River, b *River) {
+	a.Crossings = append(a.Crossings, b)
+	b.Crossings = append(b.Crossings, a)
+}
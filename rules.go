@@ -0,0 +1,177 @@
+package hashi
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Rule is a deduction pass over a board. Apply makes every change the rule
+// can justify from the board's current state and reports whether it changed
+// anything. A rule that changes the board should make its changes with
+// AddBridge and River.CapToGive so they can be rolled back, and describe each
+// one with Board.RecordMove. If Apply reports a change without recording any
+// moves, the solver records one for each river it changed, with no reason.
+type Rule interface {
+	Name() string
+	Apply(b *Board) bool
+}
+
+// ProbingRule is implemented by rules that try moves out and solve the
+// result, like MakeAGuess. AutoSolve(false), which those nested solves use,
+// skips any rule whose Probes method returns true.
+type ProbingRule interface {
+	Rule
+	Probes() bool
+}
+
+type funcRule struct {
+	name   string
+	apply  func(b *Board) bool
+	probes bool
+}
+
+func (r *funcRule) Name() string {
+	return r.name
+}
+
+func (r *funcRule) Apply(b *Board) bool {
+	return r.apply(b)
+}
+
+func (r *funcRule) Probes() bool {
+	return r.probes
+}
+
+// NewRule returns a Rule with the given name that runs fn.
+func NewRule(name string, fn func(b *Board) bool) Rule {
+	return &funcRule{name: name, apply: fn}
+}
+
+// NewProbingRule is like NewRule, but the rule is skipped by the nested
+// solves that probing rules run.
+func NewProbingRule(name string, fn func(b *Board) bool) Rule {
+	return &funcRule{name: name, apply: fn, probes: true}
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]Rule{}
+	registered   = []Rule{}
+)
+
+func init() {
+	RegisterRule(NewRule(RuleRequiredFill, (*Board).RequiredFill))
+	RegisterRule(NewRule(RuleJoinedIsolation, (*Board).CapToAvoidJoinedIsolation))
+	RegisterRule(NewRule(RuleSelfIsolation, (*Board).CapToAvoidSelfIsolation))
+	RegisterRule(NewRule(RuleBadCorners, (*Board).BadCorners))
+	RegisterRule(NewProbingRule(RuleMakeAGuess, (*Board).MakeAGuess))
+}
+
+// RegisterRule makes r available by name to LookupRule and RulesByName and
+// adds it to DefaultRules. Names must be unique.
+func RegisterRule(r Rule) error {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if _, ok := registry[r.Name()]; ok {
+		return fmt.Errorf("rule %s is already registered", r.Name())
+	}
+	registry[r.Name()] = r
+	registered = append(registered, r)
+	return nil
+}
+
+// LookupRule returns the registered rule with the given name, or nil.
+func LookupRule(name string) Rule {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return registry[name]
+}
+
+// RulesByName looks up each of the named rules, keeping their order.
+func RulesByName(names []string) ([]Rule, error) {
+	rules := []Rule{}
+	for _, name := range names {
+		r := LookupRule(name)
+		if r == nil {
+			return nil, fmt.Errorf("unknown rule %s", name)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// DefaultRules returns every registered rule in the order it was
+// registered, except that probing rules come after all of the others. The
+// built-in rules come first, from cheapest to most expensive.
+func DefaultRules() []Rule {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	rules := []Rule{}
+	probing := []Rule{}
+	for _, r := range registered {
+		if isProbing(r) {
+			probing = append(probing, r)
+		} else {
+			rules = append(rules, r)
+		}
+	}
+	return append(rules, probing...)
+}
+
+func isProbing(r Rule) bool {
+	p, ok := r.(ProbingRule)
+	return ok && p.Probes()
+}
+
+// applyRule runs rule on b and reports whether it changed anything. If the
+// rule changed the board without recording a move, the changes are credited
+// to it here: each river that gained bridges, or failing that each river
+// whose cap dropped, gets a move. The changes are found on the undo trail,
+// which is watched for the length of the call without taking a checkpoint.
+func (b *Board) applyRule(rule Rule) bool {
+	name := rule.Name()
+	recorded := b.stats.Moves[name]
+	b.marks++
+	mark := len(b.trail)
+	applied := rule.Apply(b)
+	if applied && b.stats.Moves[name] == recorded {
+		b.creditChanges(name, b.trail[mark:])
+	}
+	b.release()
+	return applied
+}
+
+// creditChanges records moves under rule for the river changes in a stretch
+// of the undo trail.
+func (b *Board) creditChanges(rule string, changes []undo) {
+	old := make(map[*int]int)
+	for _, u := range changes {
+		if _, seen := old[u.ptr]; u.kind == undoInt && !seen {
+			old[u.ptr] = u.old
+		}
+	}
+	added := []*River{}
+	capped := []*River{}
+	for _, r := range b.AllRivers {
+		if was, ok := old[&r.Bridges]; ok && r.Bridges > was {
+			added = append(added, r)
+		} else if was, ok := old[&r.ToGive]; ok && r.ToGive < was {
+			capped = append(capped, r)
+		}
+	}
+	kind, rivers := AddBridges, added
+	if len(added) == 0 {
+		kind, rivers = CapRiver, capped
+	}
+	for _, r := range rivers {
+		b.RecordMove(rule, kind, r, "no reason recorded")
+	}
+}
+
+// activeRules returns the rules b is configured to run.
+func (b *Board) activeRules() []Rule {
+	if b.Rules != nil {
+		return b.Rules
+	}
+	return DefaultRules()
+}
@@ -0,0 +1,64 @@
+package hashi
+
+import "testing"
+
+// lonelyRule bridges the only live river of an island that still needs
+// bridges, leaving the recording to the solver unless record is set.
+func lonelyRule(record bool) Rule {
+	return NewRule("Lonely", func(b *Board) bool {
+		for _, i := range b.AllIslands {
+			if len(i.LiveRivers) == 1 && i.NumNeeded() > 0 {
+				r := i.LiveRivers[0]
+				b.AddBridge(r)
+				if record {
+					b.RecordMove("Lonely", AddBridges, r, "it is the island's only river")
+				}
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func TestCustomRuleMoves(t *testing.T) {
+	for _, record := range []bool{false, true} {
+		b, err := ParseFile("problem1.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Rules = []Rule{lonelyRule(record), LookupRule(RuleRequiredFill)}
+		b.Explain = true
+		hint, err := b.NextHint()
+		if err != nil || hint == nil || hint.Rule != "Lonely" {
+			t.Fatalf("record=%v: hint %v, %v; want a Lonely move", record, hint, err)
+		}
+		res := Solve(b)
+		if !res.Solved {
+			t.Fatalf("record=%v: not solved: %v", record, res.Reason)
+		}
+		ct := res.Stats.Moves["Lonely"]
+		if ct == 0 {
+			t.Errorf("record=%v: no Lonely moves in %v", record, res.Stats.Moves)
+		}
+		explained := 0
+		for _, m := range res.Board.Moves {
+			if m.Rule == "Lonely" {
+				explained++
+			}
+		}
+		if explained != ct {
+			t.Errorf("record=%v: %d Lonely moves explained, %d counted", record, explained, ct)
+		}
+	}
+}
+
+func TestApplyRuleAllocs(t *testing.T) {
+	b, err := ParseFile("problem1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	idle := NewRule("Idle", func(b *Board) bool { return false })
+	if n := testing.AllocsPerRun(100, func() { b.applyRule(idle) }); n != 0 {
+		t.Errorf("applying a rule allocated %v times", n)
+	}
+}
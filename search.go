@@ -0,0 +1,157 @@
+package hashi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrNoSolution = errors.New("puzzle has no solution")
+
+// Search finds a solution by depth-first search over river bridge counts,
+// running the deduction rules at every node. It returns a solved copy of the
+// board, or nil if no assignment of bridges satisfies the puzzle. b itself is
+// left in whatever state the deductions at the root reached.
+func (b *Board) Search() *Board {
+	b.startPhase("Search")
+	defer b.stopPhase("Search")
+	var sol *Board
+	b.searchAll(0, false, func(s *Board) bool {
+		sol = s.Clone()
+		return false
+	})
+	return sol
+}
+
+// searchInPlace is like Search, but leaves b in the first solved state it
+// finds instead of copying it. It reports whether it found one; if not, b is
+// left where the deductions at the root left it.
+func (b *Board) searchInPlace() bool {
+	b.startPhase("Search")
+	defer b.stopPhase("Search")
+	b.searchAll(0, true, func(s *Board) bool {
+		return false
+	})
+	solved, _ := b.IsSolved()
+	return solved
+}
+
+// CountSolutions counts the puzzle's solutions, giving up once it has found
+// limit of them; a limit of 0 or less means no limit. It returns the count
+// along with the solutions it found, and leaves b untouched.
+func (b *Board) CountSolutions(limit int) (int, []*Board) {
+	ct, sols, _ := b.CountSolutionsContext(context.Background(), limit, Limits{})
+	return ct, sols
+}
+
+// CountSolutionsContext is like CountSolutions, but gives up when ctx is done
+// or the search reaches one of limits. It then returns the solutions found
+// so far, which are only a lower bound, with an error wrapping
+// ErrInterrupted.
+func (b *Board) CountSolutionsContext(ctx context.Context, limit int, limits Limits) (int, []*Board, error) {
+	b.startPhase("CountSolutions")
+	defer b.stopPhase("CountSolutions")
+	sols := []*Board{}
+	c := b.Clone()
+	c.setBudget(ctx, limits)
+	c.searchAll(0, false, func(s *Board) bool {
+		sols = append(sols, s.Clone())
+		return limit <= 0 || len(sols) < limit
+	})
+	if err := c.budget.err; err != nil {
+		return len(sols), sols, fmt.Errorf("%w: %s", ErrInterrupted, err)
+	}
+	return len(sols), sols, nil
+}
+
+// searchAll calls visit with each solution reachable from b, which is depth
+// guesses deep, until visit returns false; visit must copy the board if it
+// wants to keep it. It returns false if the search was stopped early. If keep
+// is set and visit stopped the search, b is left in the solved state rather
+// than rolled back.
+func (b *Board) searchAll(depth int, keep bool, visit func(*Board) bool) bool {
+	if b.interrupted() {
+		return false
+	}
+	b.stats.SearchNodes++
+	b.stats.SearchDepth = max(b.stats.SearchDepth, depth)
+	b.deduce(false)
+	if b.interrupted() {
+		return false
+	}
+	if m, _ := b.HasMistakes(); m {
+		return true
+	}
+	if ok, _ := b.IsSolved(); ok {
+		return visit(b)
+	}
+	r := b.branchRiver()
+	if r == nil {
+		return true
+	}
+	//try every final bridge count for r, most bridges first
+	for k := r.ToGive; k >= 0; k-- {
+		cp := b.Checkpoint()
+		ok := true
+		for j := 0; j < k; j++ {
+			if b.AddBridge(r) != nil {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			r.SetToGive(0)
+			kind := AddBridges
+			if k == 0 {
+				kind = CapRiver
+			}
+			b.addMove(RuleSearch, kind, r, nil, func() string {
+				return fmt.Sprintf("the deductions stalled, so the search tries settling %s at %d", riverLabel(r), r.Bridges)
+			})
+			ok = b.searchAll(depth+1, keep, visit)
+			if !ok {
+				if solved, _ := b.IsSolved(); keep && solved {
+					b.Commit(cp)
+				} else {
+					b.Rollback(cp)
+				}
+				return false
+			}
+			b.Rollback(cp)
+			continue
+		}
+		b.Rollback(cp)
+	}
+	return true
+}
+
+// branchRiver picks the river to branch on: a live river of the incomplete
+// island with the fewest live rivers, breaking ties by the least slack
+// between the bridges it could still get and the bridges it needs.
+func (b *Board) branchRiver() *River {
+	var best *Island
+	for _, i := range b.AllIslands {
+		if i.IsComplete() || len(i.LiveRivers) == 0 {
+			continue
+		}
+		if best == nil || len(i.LiveRivers) < len(best.LiveRivers) ||
+			(len(i.LiveRivers) == len(best.LiveRivers) && i.Available-i.NumNeeded() < best.Available-best.NumNeeded()) {
+			best = i
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.LiveRivers[0]
+}
+
+// Counterpart returns the river on b that joins the same two cells as r,
+// which usually belongs to a different copy of the board.
+func (b *Board) Counterpart(r *River) *River {
+	ia := b.Grid[r.Islands[0].R][r.Islands[0].C]
+	ib := b.Grid[r.Islands[1].R][r.Islands[1].C]
+	if ia == nil || ib == nil {
+		return nil
+	}
+	return ia.RiverWith(ib)
+}
@@ -0,0 +1,219 @@
+package hashi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BoardFromSolution reads a solved or partly solved grid in the format
+// String2 draws: island digits, spaces or '.' for open water, and the bridge
+// symbols - = | " + F H #. It returns the puzzle with each river's bridges
+// filled in, which may or may not be a valid solution. Rows that lost their
+// trailing spaces are padded back out with water, but since the puzzle's
+// size is not known, blank rows at the top or bottom are dropped; use
+// ApplySolution on the puzzle to keep them.
+func BoardFromSolution(data string) (*Board, error) {
+	rows := drawingRows(data)
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("board has no rows")
+	}
+	clues := make([]string, len(rows))
+	for ri, row := range rows {
+		clue := []rune(row)
+		for ci, ch := range clue {
+			if ch < '1' || ch > '8' {
+				clue[ci] = '.'
+			}
+		}
+		clues[ri] = string(clue)
+	}
+	b, err := BoardFromString(strings.Join(clues, "\n"))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.checkDrawable(); err != nil {
+		return nil, err
+	}
+	if err := b.applyDrawing(rows); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ApplySolution puts the bridges from a drawn grid, in the format
+// BoardFromSolution reads, onto b. The grid's islands must match b's. Rows of
+// open water that lost their spaces, even at the top or bottom of the grid,
+// are padded back out to b's size.
+func (b *Board) ApplySolution(data string) error {
+	if err := b.checkDrawable(); err != nil {
+		return err
+	}
+	rows, err := b.fitDrawing(data)
+	if err != nil {
+		return err
+	}
+	for ri, row := range rows {
+		for ci, ch := range []rune(row) {
+			i := b.Grid[ri][ci]
+			isIsland := ch >= '1' && ch <= '8'
+			if (i == nil && isIsland) || (i != nil && (!isIsland || int(ch-'0') != i.Num)) {
+				return fmt.Errorf("line %d, column %d: solution has %q, which does not match the puzzle", ri+1, ci+1, ch)
+			}
+		}
+	}
+	return b.applyDrawing(rows)
+}
+
+// drawingRows splits a drawn grid into rows of equal width, treating '.' as
+// water and dropping blank lines before the first row and after the last.
+func drawingRows(data string) []string {
+	lines := strings.Split(strings.ReplaceAll(data, ".", " "), "\n")
+	for idx := range lines {
+		lines[idx] = strings.TrimRight(lines[idx], "\r")
+	}
+	for len(lines) > 0 && len(lines[0]) == 0 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	width := 0
+	for _, line := range lines {
+		width = max(width, len([]rune(line)))
+	}
+	for idx, line := range lines {
+		lines[idx] = line + strings.Repeat(" ", width-len([]rune(line)))
+	}
+	return lines
+}
+
+// fitDrawing splits a drawn grid for b the way drawingRows does, except that
+// blank lines may stand for rows of open water. Blank lines at either end
+// are only dropped while there are more lines than b has rows, and missing
+// rows at the bottom are filled in with water.
+func (b *Board) fitDrawing(data string) ([]string, error) {
+	lines := strings.Split(strings.ReplaceAll(data, ".", " "), "\n")
+	for idx := range lines {
+		lines[idx] = strings.TrimRight(lines[idx], "\r")
+	}
+	for len(lines) > b.Rows && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for len(lines) > b.Rows && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) > b.Rows {
+		return nil, fmt.Errorf("board has %d rows, but the solution has %d", b.Rows, len(lines))
+	}
+	for len(lines) < b.Rows {
+		lines = append(lines, "")
+	}
+	for ri, line := range lines {
+		width := len([]rune(line))
+		if width > b.Cols {
+			return nil, fmt.Errorf("board has %d cols, but row %d of the solution has %d cells", b.Cols, ri, width)
+		}
+		lines[ri] = line + strings.Repeat(" ", b.Cols-width)
+	}
+	return lines, nil
+}
+
+// checkDrawable returns an error if b has two islands side by side. A drawn
+// grid has no cell between them to show their bridges in, so it cannot
+// describe a solution to such a puzzle; a bridge list can.
+func (b *Board) checkDrawable() error {
+	for _, r := range b.AllRivers {
+		ia, ib := r.Islands[0], r.Islands[1]
+		if (ia.R == ib.R && max(ia.C, ib.C)-min(ia.C, ib.C) == 1) || (ia.C == ib.C && max(ia.R, ib.R)-min(ia.R, ib.R) == 1) {
+			return fmt.Errorf("%s and %s are side by side, so a drawn grid cannot show the bridges between them; use a bridge list instead", islandLabel(ia), islandLabel(ib))
+		}
+	}
+	return nil
+}
+
+func horizontalBridges(ch rune) int {
+	switch ch {
+	case '-', '+', 'H':
+		return 1
+	case '=', 'F', '#':
+		return 2
+	}
+	return 0
+}
+
+func verticalBridges(ch rune) int {
+	switch ch {
+	case '|', '+', 'F':
+		return 1
+	case '"', 'H', '#':
+		return 2
+	}
+	return 0
+}
+
+// applyDrawing is drawingViolations for callers that only want to know
+// about the first problem.
+func (b *Board) applyDrawing(rows []string) error {
+	if vs := b.drawingViolations(rows); len(vs) > 0 {
+		return fmt.Errorf("line %d, column %d: %s", vs[0].Row+1, vs[0].Col+1, vs[0].Message)
+	}
+	return nil
+}
+
+// drawingViolations reads each river's bridge count from the first cell it
+// crosses in rows and places that many bridges, then reports every bridge
+// cell that drawing the result does not reproduce. Island cells are left to
+// the caller to check.
+func (b *Board) drawingViolations(rows []string) []Violation {
+	grid := make([][]rune, len(rows))
+	for ri, row := range rows {
+		grid[ri] = []rune(row)
+	}
+	for _, r := range b.AllRivers {
+		ia, ib := r.Islands[0], r.Islands[1]
+		ct := 0
+		if ia.R == ib.R && min(ia.C, ib.C)+1 < max(ia.C, ib.C) {
+			ct = horizontalBridges(grid[ia.R][min(ia.C, ib.C)+1])
+		} else if ia.C == ib.C && min(ia.R, ib.R)+1 < max(ia.R, ib.R) {
+			ct = verticalBridges(grid[min(ia.R, ib.R)+1][ia.C])
+		}
+		if ct > 0 {
+			b.forceBridges(r, ct)
+		}
+	}
+	vs := []Violation{}
+	drawn := strings.Split(b.String(), "\n")
+	for ri := range grid {
+		want := []rune(drawn[ri])
+		for ci, ch := range grid[ri] {
+			if ch == want[ci] || (ch >= '1' && ch <= '8') || b.Grid[ri][ci] != nil {
+				continue
+			}
+			msg := ""
+			if want[ci] == ' ' {
+				msg = fmt.Sprintf("%q is not part of a bridge between two islands", ch)
+			} else if ch == ' ' {
+				msg = fmt.Sprintf("the bridge is broken here; expected %q", want[ci])
+			} else {
+				msg = fmt.Sprintf("found %q, but the rest of the bridges here draw %q", ch, want[ci])
+			}
+			vs = append(vs, Violation{Kind: BadBridge, Row: ri, Col: ci, Message: msg})
+		}
+	}
+	return vs
+}
+
+// forceBridges puts ct bridges on r whether or not the rules allow them, so
+// that mistaken solutions can be read in and checked.
+func (b *Board) forceBridges(r *River, ct int) {
+	b.setInt(&r.Bridges, ct)
+	b.setToGive(r, max(0, min(r.ToGive, r.Max-ct)))
+	r.Islands[0].Update()
+	r.Islands[1].Update()
+	if ct > 0 {
+		b.joinClusters(r.Islands[0].Cluster(), r.Islands[1].Cluster())
+	}
+	for _, crossingRiver := range r.Crossings {
+		crossingRiver.SetToGive(0)
+	}
+}
@@ -0,0 +1,115 @@
+package hashi
+
+import (
+	"strings"
+	"testing"
+)
+
+func sameBridges(t *testing.T, got *Board, want *Board, where string) {
+	t.Helper()
+	for _, r := range want.AllRivers {
+		other := got.Counterpart(r)
+		if other == nil {
+			t.Fatalf("%s: no river %s", where, r)
+		}
+		if other.Bridges != r.Bridges {
+			t.Fatalf("%s: %s has %d bridges, want %d", where, r, other.Bridges, r.Bridges)
+		}
+	}
+}
+
+func TestSolutionRoundTrip(t *testing.T) {
+	puzzle, err := ParseFile("problem1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := Solve(puzzle)
+	drawn := res.Board.String()
+	b, err := BoardFromSolution(drawn)
+	if err != nil {
+		t.Fatalf("BoardFromSolution: %v", err)
+	}
+	sameBridges(t, b, res.Board, "BoardFromSolution")
+	applied := puzzle.Clone()
+	if err := applied.ApplySolution(drawn); err != nil {
+		t.Fatalf("ApplySolution: %v", err)
+	}
+	sameBridges(t, applied, res.Board, "ApplySolution")
+}
+
+func TestSolutionRaggedRows(t *testing.T) {
+	//the first and last rows lost their trailing spaces
+	b, err := BoardFromSolution(" 2\n1\"1\n 1\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Rows != 3 || b.Cols != 3 {
+		t.Fatalf("read a %dx%d board", b.Cols, b.Rows)
+	}
+	if r := b.Grid[0][1].RiverWith(b.Grid[2][1]); r.Bridges != 2 {
+		t.Errorf("vertical river has %d bridges, want 2", r.Bridges)
+	}
+	if r := b.Grid[1][0].RiverWith(b.Grid[1][2]); r.Bridges != 0 {
+		t.Errorf("horizontal river has %d bridges, want 0", r.Bridges)
+	}
+}
+
+func TestSolutionCrossing(t *testing.T) {
+	b, err := BoardFromSolution(".1.\n1+1\n.1.\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range b.AllRivers {
+		if r.Bridges != 1 {
+			t.Errorf("%s has %d bridges, want 1", r, r.Bridges)
+		}
+	}
+	if solved, _ := b.IsSolved(); solved {
+		t.Errorf("crossing bridges read as a solution")
+	}
+}
+
+func TestSolutionMismatch(t *testing.T) {
+	puzzle := mustParse(t, "2.2\n...\n2.2\n")
+	for _, drawn := range []string{
+		"2=2\n...\n2=1\n",
+		"2-2\n|.|\n2-2\n|.|\n",
+		"2=2\n.-.\n2=2\n",
+	} {
+		if err := puzzle.Clone().ApplySolution(drawn); err == nil {
+			t.Errorf("%q: no error", drawn)
+		}
+	}
+}
+
+func TestSolutionWaterRows(t *testing.T) {
+	puzzle, err := Parse("....\n2..2\n....\n....\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//editors trim the blank rows' spaces, and sometimes the rows themselves
+	for _, drawn := range []string{"\n2==2\n", "\n2==2\n\n\n", "    \n2==2\n    \n    \n", "\n2==2"} {
+		v, err := VerifySolution(puzzle, drawn)
+		if err != nil || !v.Solved {
+			t.Errorf("%q: VerifySolution: %v, %v", drawn, v, err)
+		}
+	}
+	if _, err := VerifySolution(puzzle, "\n2==2\n\n\n\n1\n"); err == nil {
+		t.Errorf("a solution with too many rows was accepted")
+	}
+}
+
+func TestSolutionSideBySide(t *testing.T) {
+	puzzle, err := Parse("11\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = VerifySolution(puzzle, "1-1\n")
+	if err == nil || !strings.Contains(err.Error(), "side by side") {
+		t.Errorf("drawn solution for side-by-side islands: got error %v", err)
+	}
+	v, err := VerifySolution(puzzle, "0 0 0 1 1\n")
+	if err != nil || !v.Solved {
+		t.Errorf("bridge list for side-by-side islands: %v, %v", v, err)
+	}
+}
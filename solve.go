@@ -0,0 +1,289 @@
+package hashi
+
+import "fmt"
+
+func (b *Board) RequiredFill() bool {
+	changed := false
+	for _, island := range b.AllIslands {
+		island, need := island, island.NumNeeded()
+		changed = b.mustProvide(RuleRequiredFill, island.LiveRivers, need, []*Island{island}, func() string {
+			return fmt.Sprintf("%s needs %d more", islandLabel(island), need)
+		}) || changed
+	}
+	return changed
+}
+
+func (b *Board) CapToAvoidJoinedIsolation() bool {
+	changed := false
+	if len(b.Clusters) <= 2 {
+		return changed
+	}
+	for _, c := range b.Clusters {
+		if c.NumEdges() != 1 {
+			continue
+		}
+		i := c.Edges()[0]
+		for _, r := range i.LiveRivers {
+			n := r.Neighbor(i)
+			if n.Cluster().NumEdges() != 1 {
+				continue
+			}
+			if n.NumNeeded() != i.NumNeeded() {
+				continue
+			}
+			if r.CapToGive(n.NumNeeded() - 1) {
+				b.addMove(RuleJoinedIsolation, CapRiver, r, []*Island{i, n}, func() string {
+					return fmt.Sprintf("%s and %s each need %d and are their clusters' only ways out, so filling this river would cut both clusters off", islandLabel(i), islandLabel(n), n.NumNeeded())
+				})
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func (b *Board) CapToAvoidSelfIsolation() bool {
+	changed := false
+	if len(b.Clusters) <= 2 {
+		return changed
+	}
+	for _, c := range b.Clusters {
+		incomplete := c.IncompleteIslands()
+		if len(incomplete) != 2 {
+			continue
+		}
+		r := incomplete[0].RiverWith(incomplete[1])
+		if r == nil {
+			continue
+		}
+		if incomplete[0].NumNeeded() != incomplete[1].NumNeeded() || incomplete[0].NumNeeded() < r.ToGive {
+			continue
+		}
+		need := incomplete[0].NumNeeded()
+		if r.CapToGive(need - 1) {
+			b.addMove(RuleSelfIsolation, CapRiver, r, incomplete, func() string {
+				return fmt.Sprintf("%s and %s are the last incomplete islands in their cluster and each need %d, so putting all %d here would cut the cluster off", islandLabel(incomplete[0]), islandLabel(incomplete[1]), need, need)
+			})
+			changed = true
+		}
+	}
+	return changed
+}
+
+// MustProvide adds every bridge that rivers are forced to carry if together
+// they have to provide ct more bridges, recording the additions under
+// RuleMustProvide.
+func (b *Board) MustProvide(rivers []*River, ct int) bool {
+	return b.mustProvide(RuleMustProvide, rivers, ct, nil, func() string {
+		return fmt.Sprintf("these rivers must provide %d", ct)
+	})
+}
+
+// mustProvide adds every bridge that rivers are forced to carry if together
+// they have to provide ct more bridges, recording the additions under rule.
+// why describes the requirement for the explanation.
+func (b *Board) mustProvide(rule string, rivers []*River, ct int, islands []*Island, why func() string) bool {
+	changed := false
+	avail := 0
+	for _, r := range rivers {
+		avail += r.ToGive
+	}
+	excess := avail - ct
+	for _, r := range rivers {
+		r := r
+		toAdd := r.ToGive - excess
+		added := 0
+		for i := 0; i < toAdd; i++ {
+			if b.AddBridge(r) == nil {
+				added++
+			}
+		}
+		if added > 0 {
+			others := avail - toAdd - excess
+			b.addMove(rule, AddBridges, r, islands, func() string {
+				return fmt.Sprintf("%s, and the other rivers can give at most %d, so %s must carry %d more", why(), others, riverLabel(r), added)
+			})
+			changed = true
+		}
+	}
+	return changed
+}
+
+// TODO: should we switch to directional river pointers instead of doing all this looping?
+func (b *Board) BadCorners() bool {
+	changed := false
+	//grab a "corner" pair of rivers
+	//find all neighbors with TWO rivers intersected by that corner
+	//if the neighbor no longer has enough, we have an impermissible corner
+	//max permissible in that pair is max(r1.ToGive, r2.ToGive)
+	//if current island needs more, then we have to get it from the other two (?) rivers
+	for _, i := range b.AllIslands {
+		if i.IsComplete() || len(i.LiveRivers) < 2 {
+			continue
+		}
+		emptyRivers := []*River{}
+		for _, r := range i.LiveRivers {
+			if r.Bridges == 0 {
+				emptyRivers = append(emptyRivers, r)
+			}
+		}
+		if len(emptyRivers) < 2 {
+			continue
+		}
+		for ri := 0; ri < len(emptyRivers); ri++ {
+			for rj := ri + 1; rj < len(emptyRivers); rj++ {
+				hitIslands := make(map[*Island]int)
+
+				for _, crossed := range emptyRivers[ri].Crossings {
+					hitIslands[crossed.Islands[0]]++
+					hitIslands[crossed.Islands[1]]++
+				}
+				for _, crossed := range emptyRivers[rj].Crossings {
+					hitIslands[crossed.Islands[0]]++
+					hitIslands[crossed.Islands[1]]++
+				}
+				for hitIsland, hitCount := range hitIslands {
+					if hitCount < 2 {
+						continue
+					}
+					hitLeftAfterCorner := 0
+					for _, r := range hitIsland.LiveRivers {
+						if !emptyRivers[ri].Crosses(r) && !emptyRivers[rj].Crosses(r) {
+							hitLeftAfterCorner += r.ToGive
+						}
+					}
+					if hitLeftAfterCorner >= hitIsland.NumNeeded() {
+						continue
+					}
+
+					cornerMax := max(emptyRivers[ri].ToGive, emptyRivers[rj].ToGive)
+					othersMustProvide := i.NumNeeded() - cornerMax
+					others := []*River{}
+					for _, other := range i.LiveRivers {
+						if other == emptyRivers[ri] || other == emptyRivers[rj] {
+							continue
+						}
+						others = append(others, other)
+					}
+
+					ra, rb, hit, hitNeed := emptyRivers[ri], emptyRivers[rj], hitIsland, hitIsland.NumNeeded()
+					result := b.mustProvide(RuleBadCorners, others, othersMustProvide, []*Island{i, hit}, func() string {
+						return fmt.Sprintf("bridges on both %s and %s would leave %s unable to get the %d it needs, so that corner gives %s at most %d and the rest must provide %d",
+							riverLabel(ra), riverLabel(rb), islandLabel(hit), hitNeed, islandLabel(i), cornerMax, othersMustProvide)
+					})
+					if result {
+						changed = true
+						break
+					}
+				}
+			}
+		}
+	}
+	return changed
+}
+
+func (b *Board) MakeAGuess() bool {
+	b.startPhase("MakeAGuess")
+	defer b.stopPhase("MakeAGuess")
+	for _, i := range b.AllIslands {
+		if i.IsComplete() {
+			continue
+		}
+		for _, r := range i.LiveRivers {
+			if b.interrupted() {
+				return false
+			}
+			b.stats.Probes++
+			cp := b.Checkpoint()
+			r.CapToGive(0)
+			b.deduce(false)
+			m, err := b.HasMistakes()
+			b.Rollback(cp)
+			if m {
+				b.AddBridge(r)
+				b.addMove(RuleMakeAGuess, AddBridges, r, nil, func() string {
+					return fmt.Sprintf("leaving %s empty leads to a contradiction: %s", riverLabel(r), err)
+				})
+				return true
+			}
+		}
+
+		for _, r := range i.LiveRivers {
+			if b.interrupted() {
+				return false
+			}
+			b.stats.Probes++
+			cp := b.Checkpoint()
+			toGive := r.ToGive
+			for j := 0; j < toGive; j++ {
+				b.AddBridge(r)
+			}
+			b.deduce(false)
+			m, err := b.HasMistakes()
+			b.Rollback(cp)
+			if m {
+				r.CapToGive(r.ToGive - 1)
+				b.addMove(RuleMakeAGuess, CapRiver, r, nil, func() string {
+					return fmt.Sprintf("giving %s all %d bridges it could take leads to a contradiction: %s", riverLabel(r), toGive, err)
+				})
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AutoSolve runs b's rules until none of them can make progress or the board
+// has a mistake. Whenever a rule changes the board, it starts over from the
+// first rule, so cheaper rules run to exhaustion before costlier ones get a
+// turn. Probing rules only run when allowGuess is set.
+//
+// With allowGuess set, AutoSolve falls back to a full search when the rules
+// stall and fills in the solution it finds on b, so b ends up either solved
+// or with Reason set to ErrNoSolution, unless b's limits interrupt it.
+// Without it, the result reports whether the rules alone solved the puzzle.
+func (b *Board) AutoSolve(allowGuess bool) *Result {
+	b.startPhase("AutoSolve")
+	b.deduce(allowGuess)
+	b.stopPhase("AutoSolve")
+	if allowGuess && !b.interrupted() {
+		solved, _ := b.IsSolved()
+		if m, _ := b.HasMistakes(); !solved && !m {
+			b.searchInPlace()
+		}
+	}
+	solved, reason := b.IsSolved()
+	res := &Result{Solved: solved, Board: b, Reason: reason}
+	if !solved && allowGuess {
+		res.Reason = ErrNoSolution
+	}
+	if !solved && b.interrupted() {
+		res.Interrupted = true
+		res.Reason = fmt.Errorf("%w: %s", ErrInterrupted, b.budget.err)
+	}
+	res.Stats = b.Stats()
+	return res
+}
+
+// deduce is AutoSolve without the bookkeeping, for the nested solves that
+// probes and the search run.
+func (b *Board) deduce(allowGuess bool) {
+	if m, _ := b.HasMistakes(); m {
+		return
+	}
+	rules := b.activeRules()
+	for idx := 0; idx < len(rules); idx++ {
+		if b.interrupted() {
+			return
+		}
+		if !allowGuess && isProbing(rules[idx]) {
+			continue
+		}
+		if b.applyRule(rules[idx]) {
+			if m, _ := b.HasMistakes(); m {
+				return
+			}
+			idx = -1
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package hashi
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Stats describes how a board was solved and how much work it took.
+type Stats struct {
+	// Moves counts, for each rule, the moves it made that are still on the
+	// board; moves that were tried and rolled back are not counted.
+	Moves map[string]int
+	// Probes counts the moves MakeAGuess tried out and rolled back.
+	Probes int
+	// Checkpoints and Clones count the snapshots the solver took.
+	Checkpoints int
+	Clones      int
+	// SearchNodes counts the states the search visited, and SearchDepth is
+	// the most nested guesses it needed at once.
+	SearchNodes int
+	SearchDepth int
+	// Phases holds the wall time spent in each phase of the solve, such as
+	// Search or MakeAGuess.
+	Phases map[string]time.Duration
+}
+
+func (s Stats) String() string {
+	out := "Moves:"
+	rules := []string{}
+	for rule := range s.Moves {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	for _, rule := range rules {
+		out += fmt.Sprintf(" %s=%d", rule, s.Moves[rule])
+	}
+	out += fmt.Sprintf("\nProbes: %d\nCheckpoints: %d\nClones: %d\nSearch nodes: %d\nSearch depth: %d\n", s.Probes, s.Checkpoints, s.Clones, s.SearchNodes, s.SearchDepth)
+	phases := []string{}
+	for phase := range s.Phases {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	for _, phase := range phases {
+		out += fmt.Sprintf("%s: %.4f\n", phase, s.Phases[phase].Seconds())
+	}
+	return out[:len(out)-1]
+}
+
+// Stats returns a copy of the statistics b has gathered so far.
+func (b *Board) Stats() Stats {
+	s := b.stats
+	s.Moves = make(map[string]int)
+	for rule, ct := range b.stats.Moves {
+		if ct > 0 {
+			s.Moves[rule] = ct
+		}
+	}
+	s.Phases = make(map[string]time.Duration)
+	for phase, d := range b.stats.Phases {
+		s.Phases[phase] = d
+	}
+	return s
+}
+
+func (b *Board) countMove(rule string) {
+	if b.stats.Moves == nil {
+		b.stats.Moves = make(map[string]int)
+	}
+	b.stats.Moves[rule]++
+	b.record(undo{kind: undoCount, rule: rule})
+}
+
+// startPhase starts timing the named phase of the work on b, unless it is
+// already running; nested and recursive calls are only timed once. The
+// times are kept per board so that boards can be solved concurrently.
+func (b *Board) startPhase(name string) {
+	if b.phaseStarts == nil {
+		b.phaseStarts = make(map[string]time.Time)
+	}
+	if _, ok := b.phaseStarts[name]; ok {
+		return
+	}
+	b.phaseStarts[name] = time.Now()
+}
+
+// stopPhase adds the time since the matching startPhase to the phase's
+// total in b's statistics.
+func (b *Board) stopPhase(name string) {
+	start, ok := b.phaseStarts[name]
+	if !ok {
+		return
+	}
+	delete(b.phaseStarts, name)
+	if b.stats.Phases == nil {
+		b.stats.Phases = make(map[string]time.Duration)
+	}
+	b.stats.Phases[name] += time.Since(start)
+}
@@ -0,0 +1,77 @@
+package hashi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SVGOptions configures Board.SVG.
+type SVGOptions struct {
+	Mode DrawMode
+	//CellSize is the width and height of a grid cell in pixels; 0 means 40
+	CellSize int
+}
+
+// SVG draws b as an SVG image: islands as numbered circles and bridges as
+// single or double lines between their centers. Mistakes are drawn in red.
+func (b *Board) SVG(opts SVGOptions) string {
+	cs := float64(opts.CellSize)
+	if cs <= 0 {
+		cs = 40
+	}
+	center := func(i *Island) (float64, float64) {
+		return (float64(i.C) + 0.5) * cs, (float64(i.R) + 0.5) * cs
+	}
+	out := strings.Builder{}
+	width, height := float64(b.Cols)*cs, float64(b.Rows)*cs
+	fmt.Fprintf(&out, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n", width, height, width, height)
+	fmt.Fprintf(&out, `<rect width="%g" height="%g" fill="white"/>`+"\n", width, height)
+
+	badIslands, badRivers := map[*Island]bool{}, map[*River]bool{}
+	if opts.Mode == DrawMistakes {
+		badIslands, badRivers = b.mistakenParts()
+	}
+
+	if opts.Mode != DrawPuzzle {
+		for _, r := range b.AllRivers {
+			x1, y1 := center(r.Islands[0])
+			x2, y2 := center(r.Islands[1])
+			if opts.Mode == DrawState && r.ToGive > 0 {
+				fmt.Fprintf(&out, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="#bbb" stroke-width="%g" stroke-dasharray="%g"/>`+"\n",
+					x1, y1, x2, y2, cs/40, cs/10)
+			}
+			stroke := "black"
+			if badRivers[r] {
+				stroke = "red"
+				if r.Bridges == 0 {
+					fmt.Fprintf(&out, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="red" stroke-width="%g" stroke-dasharray="%g"/>`+"\n",
+						x1, y1, x2, y2, cs/20, cs/10)
+				}
+			}
+			//parallel bridges sit side by side, centered on the line
+			//between the islands
+			gap := cs / 8
+			for k := 0; k < r.Bridges; k++ {
+				off := (float64(k) - float64(r.Bridges-1)/2) * gap
+				dx, dy := 0.0, off
+				if x1 == x2 {
+					dx, dy = off, 0
+				}
+				fmt.Fprintf(&out, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="%s" stroke-width="%g"/>`+"\n",
+					x1+dx, y1+dy, x2+dx, y2+dy, stroke, cs/20)
+			}
+		}
+	}
+
+	for _, i := range b.AllIslands {
+		x, y := center(i)
+		fill, stroke := "white", "black"
+		if badIslands[i] {
+			fill, stroke = "#fcc", "red"
+		}
+		fmt.Fprintf(&out, `<circle cx="%g" cy="%g" r="%g" fill="%s" stroke="%s" stroke-width="%g"/>`+"\n", x, y, cs*0.4, fill, stroke, cs/20)
+		fmt.Fprintf(&out, `<text x="%g" y="%g" font-family="sans-serif" font-size="%g" text-anchor="middle" dominant-baseline="central">%d</text>`+"\n", x, y, cs/2, i.Num)
+	}
+	out.WriteString("</svg>\n")
+	return out.String()
+}
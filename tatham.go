@@ -0,0 +1,126 @@
+package hashi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BoardFromTatham reads a game ID from the Bridges game in Simon Tatham's
+// Portable Puzzle Collection, such as "7x7m2:a2b3...". The parameters before
+// the colon give the width, the height and, after 'm', the most bridges one
+// river can hold, which must be 1 or 2; games with m3 or m4, and games that
+// forbid loops (L), are refused. Options that only steer that game's
+// generator (i, e and d) are ignored. After the colon, each island is its
+// count, 1-8, and each lowercase letter is a run of that many empty cells,
+// a for 1 through z for 26. The game writes counts above 9 as A-G, but
+// those only occur with m3 or m4.
+func BoardFromTatham(id string) (*Board, error) {
+	params, desc, ok := strings.Cut(strings.TrimSpace(id), ":")
+	if !ok {
+		return nil, fmt.Errorf("game ID %q has no ':'", id)
+	}
+	rows, cols, maxb, err := parseTathamParams(params)
+	if err != nil {
+		return nil, err
+	}
+	b := Board{Grid: make([][]*Island, rows), Rows: rows, Cols: cols, MaxBridges: maxb, Clusters: []*Cluster{}, AllRivers: []*River{}, AllIslands: []*Island{}}
+	for ri := range b.Grid {
+		b.Grid[ri] = make([]*Island, cols)
+	}
+	cell := 0
+	for idx, ch := range desc {
+		if cell >= rows*cols {
+			return nil, fmt.Errorf("game ID describes more than %dx%d cells at position %d", cols, rows, idx)
+		}
+		num := 0
+		switch {
+		case ch >= 'a' && ch <= 'z':
+			cell += int(ch-'a') + 1
+			continue
+		case ch >= '1' && ch <= '9':
+			num = int(ch - '0')
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d of the game ID", ch, idx)
+		}
+		if num > 4*maxb {
+			return nil, fmt.Errorf("island at position %d needs %d bridges, but it can have at most %d", idx, num, 4*maxb)
+		}
+		b.AddIsland(num, cell/cols, cell%cols)
+		cell++
+	}
+	if cell != rows*cols {
+		return nil, fmt.Errorf("game ID describes %d cells; %dx%d needs %d", cell, cols, rows, rows*cols)
+	}
+	b.CreateRivers()
+	return &b, nil
+}
+
+// parseTathamParams reads the part of a game ID before the colon: "7" or
+// "7x5", followed by options that are each a letter and usually a number.
+func parseTathamParams(params string) (rows int, cols int, maxb int, err error) {
+	num := func() int {
+		end := 0
+		for end < len(params) && params[end] >= '0' && params[end] <= '9' {
+			end++
+		}
+		n, _ := strconv.Atoi(params[:end])
+		params = params[end:]
+		return n
+	}
+	cols = num()
+	rows = cols
+	if strings.HasPrefix(params, "x") {
+		params = params[1:]
+		rows = num()
+	}
+	if rows < 1 || cols < 1 {
+		return 0, 0, 0, fmt.Errorf("game ID has no valid size")
+	}
+	maxb = 2
+	for len(params) > 0 {
+		opt := params[0]
+		params = params[1:]
+		switch opt {
+		case 'm':
+			maxb = num()
+		case 'L':
+			return 0, 0, 0, fmt.Errorf("game ID forbids loops (L), which is not supported")
+		default:
+			num()
+		}
+	}
+	if maxb < 1 || maxb > 2 {
+		return 0, 0, 0, fmt.Errorf("game ID allows %d bridges per river; only m1 and m2 are supported", maxb)
+	}
+	return rows, cols, maxb, nil
+}
+
+// TathamID encodes the puzzle, without any bridges, as a game ID that
+// BoardFromTatham and the Bridges game can read.
+func (b *Board) TathamID() string {
+	desc := strings.Builder{}
+	run := 0
+	flush := func() {
+		if run > 0 {
+			desc.WriteByte(byte('a' + run - 1))
+			run = 0
+		}
+	}
+	for ri := 0; ri < b.Rows; ri++ {
+		for ci := 0; ci < b.Cols; ci++ {
+			i := b.Grid[ri][ci]
+			if i == nil {
+				if run == 26 {
+					flush()
+				}
+				run++
+				continue
+			}
+			flush()
+			desc.WriteByte(byte('0' + i.Num))
+		}
+	}
+	flush()
+	return fmt.Sprintf("%dx%dm%d:%s", b.Cols, b.Rows, b.MaxBridges, desc.String())
+}
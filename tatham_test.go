@@ -0,0 +1,71 @@
+package hashi
+
+import "testing"
+
+func TestTathamParams(t *testing.T) {
+	b, err := BoardFromTatham("3x1m1i30e10:1a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Rows != 1 || b.Cols != 3 || b.MaxBridges != 1 || b.AllRivers[0].Max != 1 {
+		t.Errorf("got %dx%d with max %d", b.Cols, b.Rows, b.MaxBridges)
+	}
+	if id := b.TathamID(); id != "3x1m1:1a1" {
+		t.Errorf("TathamID: %q", id)
+	}
+	//a lone size is square, and m defaults to 2
+	b, err = BoardFromTatham("3:1a1c1a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Rows != 3 || b.Cols != 3 || b.MaxBridges != 2 {
+		t.Errorf("got %dx%d with max %d", b.Cols, b.Rows, b.MaxBridges)
+	}
+	for _, bad := range []string{"3x1", "3x1m3:1a1", "3x1L:1a1", "3x1:1a", "3x1:1a1a", "3x1m1:5a1", "x1:1a1", "3x1:1A1"} {
+		if _, err := BoardFromTatham(bad); err == nil {
+			t.Errorf("%q: no error", bad)
+		}
+	}
+}
+
+func TestTathamLongRun(t *testing.T) {
+	//28 empty cells take two letters
+	id := "30x1m2:1zb1"
+	b, err := BoardFromTatham(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.AllIslands) != 2 || b.Grid[0][29] == nil {
+		t.Fatalf("islands at the wrong cells:\n%s", b.PuzzleString())
+	}
+	if again := b.TathamID(); again != id {
+		t.Errorf("game ID %q came back as %q", id, again)
+	}
+}
+
+func TestVerifyKeepsMaxBridges(t *testing.T) {
+	b, err := BoardFromTatham("3x1m1:2a2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := VerifySolution(b, "2=2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Solved {
+		t.Errorf("a double bridge verified on a puzzle that allows one")
+	}
+}
+
+func TestHandBuiltBoardMaxBridges(t *testing.T) {
+	b := &Board{Rows: 1, Cols: 3, Grid: [][]*Island{make([]*Island, 3)}}
+	b.AddIsland(2, 0, 0)
+	b.AddIsland(2, 0, 2)
+	b.CreateRivers()
+	if r := b.AllRivers[0]; r.Max != 2 || r.ToGive != 2 {
+		t.Fatalf("river has max %d, toGive %d; want 2, 2", r.Max, r.ToGive)
+	}
+	if res := Solve(b); !res.Solved {
+		t.Errorf("not solved: %v", res.Reason)
+	}
+}
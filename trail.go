@@ -0,0 +1,85 @@
+package hashi
+
+type undoKind int
+
+const (
+	undoInt undoKind = iota
+	undoLiveRivers
+	undoJoin
+	undoMove
+	undoCount
+)
+
+// undo records one change to a board so that Rollback can reverse it.
+type undo struct {
+	kind    undoKind
+	ptr     *int
+	old     int
+	island  *Island
+	rivers  []*River
+	cluster *Cluster
+	rule    string
+}
+
+// Checkpoint starts recording changes to b and returns a mark that Rollback
+// can later restore. Checkpoints nest; each one must be closed by exactly one
+// call to Rollback or Commit, innermost first.
+func (b *Board) Checkpoint() int {
+	b.marks++
+	b.stats.Checkpoints++
+	return len(b.trail)
+}
+
+// Rollback undoes every change made since the checkpoint cp was taken and
+// closes it.
+func (b *Board) Rollback(cp int) {
+	for len(b.trail) > cp {
+		u := b.trail[len(b.trail)-1]
+		b.trail = b.trail[:len(b.trail)-1]
+		switch u.kind {
+		case undoInt:
+			*u.ptr = u.old
+		case undoLiveRivers:
+			u.island.LiveRivers = u.rivers
+		case undoJoin:
+			b.splitClusters(u.cluster, u.old)
+		case undoMove:
+			b.Moves = b.Moves[:len(b.Moves)-1]
+		case undoCount:
+			b.stats.Moves[u.rule]--
+		}
+	}
+	b.release()
+}
+
+// Commit closes the checkpoint cp, keeping the changes made since it was
+// taken. They can still be undone by rolling back an enclosing checkpoint.
+func (b *Board) Commit(cp int) {
+	b.release()
+}
+
+func (b *Board) release() {
+	b.marks--
+	if b.marks == 0 {
+		b.trail = b.trail[:0]
+	}
+}
+
+func (b *Board) record(u undo) {
+	if b.marks > 0 {
+		b.trail = append(b.trail, u)
+	}
+}
+
+func (b *Board) setInt(p *int, v int) {
+	if *p == v {
+		return
+	}
+	b.record(undo{kind: undoInt, ptr: p, old: *p})
+	*p = v
+}
+
+func (b *Board) setLiveRivers(i *Island, rivers []*River) {
+	b.record(undo{kind: undoLiveRivers, island: i, rivers: i.LiveRivers})
+	i.LiveRivers = rivers
+}
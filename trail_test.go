@@ -0,0 +1,129 @@
+package hashi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, data string) *Board {
+	t.Helper()
+	b, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// snapshot describes everything about b's state that Rollback has to
+// restore: bridge counts and caps, each island's counts and live rivers, and
+// the clusters in b.Clusters order with their counts.
+func snapshot(b *Board) string {
+	index := make(map[*River]int)
+	var sb strings.Builder
+	for idx, r := range b.AllRivers {
+		index[r] = idx
+		fmt.Fprintf(&sb, "r%d %d/%d\n", idx, r.Bridges, r.ToGive)
+	}
+	for _, i := range b.AllIslands {
+		fmt.Fprintf(&sb, "(r%d, c%d) %d a%d live", i.R, i.C, i.Bridges, i.Available)
+		for _, r := range i.LiveRivers {
+			fmt.Fprintf(&sb, " %d", index[r])
+		}
+		sb.WriteString("\n")
+	}
+	for _, c := range b.Clusters {
+		members := []string{}
+		for _, i := range c.Islands() {
+			members = append(members, fmt.Sprintf("(r%d, c%d)", i.R, i.C))
+		}
+		sort.Strings(members)
+		fmt.Fprintf(&sb, "cluster %v need %d exits %d edges %d\n", members, c.Need(), c.NumExits(), c.NumEdges())
+	}
+	return sb.String()
+}
+
+func TestRollbackAfterJoin(t *testing.T) {
+	b := mustParse(t, "2.2\n...\n2.2\n")
+	before := snapshot(b)
+	cp := b.Checkpoint()
+	b.AddBridge(b.Grid[0][0].RiverWith(b.Grid[0][2]))
+	b.AddBridge(b.Grid[0][0].RiverWith(b.Grid[2][0]))
+	if len(b.Clusters) != 2 {
+		t.Fatalf("%d clusters after joining three islands, want 2", len(b.Clusters))
+	}
+	b.Rollback(cp)
+	if got := snapshot(b); got != before {
+		t.Fatalf("rollback left\n%s\nwant\n%s", got, before)
+	}
+	for _, i := range b.AllIslands {
+		if i.Cluster().Size() != 1 || !i.Cluster().Contains(i) {
+			t.Errorf("island (r%d, c%d) is in %v", i.R, i.C, i.Cluster())
+		}
+	}
+	if len(b.trail) != 0 {
+		t.Errorf("trail holds %d changes after the last checkpoint closed", len(b.trail))
+	}
+}
+
+func TestRollbackCrossingCap(t *testing.T) {
+	b := mustParse(t, ".1.\n1.1\n.1.\n")
+	across := b.Grid[1][0].RiverWith(b.Grid[1][2])
+	down := b.Grid[0][1].RiverWith(b.Grid[2][1])
+	if !across.Crosses(down) {
+		t.Fatalf("rivers do not cross")
+	}
+	before := snapshot(b)
+	cp := b.Checkpoint()
+	b.AddBridge(across)
+	if down.ToGive != 0 || len(b.Grid[0][1].LiveRivers) != 0 {
+		t.Fatalf("crossing river still has %d to give", down.ToGive)
+	}
+	b.Rollback(cp)
+	if got := snapshot(b); got != before {
+		t.Fatalf("rollback left\n%s\nwant\n%s", got, before)
+	}
+}
+
+func TestNestedCheckpoints(t *testing.T) {
+	b := mustParse(t, "2.2\n...\n2.2\n")
+	top := b.Grid[0][0].RiverWith(b.Grid[0][2])
+	left := b.Grid[0][0].RiverWith(b.Grid[2][0])
+	bottom := b.Grid[2][0].RiverWith(b.Grid[2][2])
+	before := snapshot(b)
+	outer := b.Checkpoint()
+	b.AddBridge(top)
+	middle := snapshot(b)
+	inner := b.Checkpoint()
+	b.AddBridge(left)
+	bottom.SetToGive(1)
+	b.Rollback(inner)
+	if got := snapshot(b); got != middle {
+		t.Fatalf("inner rollback left\n%s\nwant\n%s", got, middle)
+	}
+	inner = b.Checkpoint()
+	b.AddBridge(left)
+	b.Commit(inner)
+	if left.Bridges != 1 {
+		t.Fatalf("commit lost the inner bridge")
+	}
+	b.Rollback(outer)
+	if got := snapshot(b); got != before {
+		t.Fatalf("outer rollback left\n%s\nwant\n%s", got, before)
+	}
+}
+
+func TestSearchLeavesBoardUntouched(t *testing.T) {
+	b, err := ParseFile("problem1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := snapshot(b)
+	if ct, _ := b.CountSolutions(0); ct != 1 {
+		t.Errorf("%d solutions, want 1", ct)
+	}
+	if got := snapshot(b); got != before {
+		t.Errorf("CountSolutions changed the board")
+	}
+}
@@ -0,0 +1,107 @@
+package hashi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Verification describes the outcome of a call to Verify or VerifySolution.
+type Verification struct {
+	// Solved reports whether the board is a complete, valid solution.
+	Solved bool
+	// Reason describes the first problem found; it is nil when Solved is
+	// true.
+	Reason error
+	// Violations lists every problem found.
+	Violations []Violation
+}
+
+func newVerification(vs []Violation) *Verification {
+	v := &Verification{Solved: len(vs) == 0, Violations: vs}
+	if len(vs) > 0 {
+		v.Reason = errors.New(vs[0].String())
+	}
+	return v
+}
+
+// Verify checks whether the bridges on b form a valid solution.
+func Verify(b *Board) *Verification {
+	return newVerification(b.Violations())
+}
+
+// VerifySolution checks a proposed solution against the puzzle's clues from
+// scratch, ignoring any bridges already on puzzle. The solution is either a
+// grid drawn the way String2 draws it or a bridge list. It returns an error
+// only if the solution cannot be read at all, which includes any drawn grid
+// for a puzzle with islands side by side.
+func VerifySolution(puzzle *Board, solution string) (*Verification, error) {
+	b := puzzle.emptyCopy()
+	vs := []Violation{}
+	if IsBridgeList(solution) {
+		bridges, _ := parseBridgeList(solution)
+		vs = append(vs, b.listViolations(bridges)...)
+	} else {
+		if err := b.checkDrawable(); err != nil {
+			return nil, err
+		}
+		rows, err := b.fitDrawing(solution)
+		if err != nil {
+			return nil, err
+		}
+		vs = append(vs, b.islandViolations(rows)...)
+		vs = append(vs, b.drawingViolations(rows)...)
+	}
+	vs = append(vs, b.Violations()...)
+	return newVerification(vs), nil
+}
+
+// islandViolations reports each cell where a drawn grid's islands differ
+// from the puzzle's.
+func (b *Board) islandViolations(rows []string) []Violation {
+	vs := []Violation{}
+	for ri, row := range rows {
+		for ci, ch := range []rune(row) {
+			i := b.Grid[ri][ci]
+			isIsland := ch >= '1' && ch <= '8'
+			switch {
+			case i == nil && isIsland:
+				vs = append(vs, Violation{Kind: ClueMismatch, Row: ri, Col: ci, Message: fmt.Sprintf("the solution has a %c here, but the puzzle has no island", ch)})
+			case i != nil && !isIsland:
+				vs = append(vs, Violation{Kind: ClueMismatch, Row: ri, Col: ci, Message: fmt.Sprintf("the puzzle's %d is missing", i.Num)})
+			case i != nil && int(ch-'0') != i.Num:
+				vs = append(vs, Violation{Kind: ClueMismatch, Row: ri, Col: ci, Message: fmt.Sprintf("the solution has a %c here, but the puzzle has a %d", ch, i.Num)})
+			}
+		}
+	}
+	return vs
+}
+
+// listViolations places the bridges from a bridge list without checking
+// them against the rules, and reports the ones that do not join two
+// neighboring islands.
+func (b *Board) listViolations(bridges []listedBridge) []Violation {
+	vs := []Violation{}
+	for _, lb := range bridges {
+		ia, ib := b.IslandAt(lb.r1, lb.c1), b.IslandAt(lb.r2, lb.c2)
+		if ia == nil {
+			vs = append(vs, Violation{Kind: BadBridge, Row: lb.r1, Col: lb.c1, Message: fmt.Sprintf("line %d: the bridge ends where there is no island", lb.line)})
+		}
+		if ib == nil {
+			vs = append(vs, Violation{Kind: BadBridge, Row: lb.r2, Col: lb.c2, Message: fmt.Sprintf("line %d: the bridge ends where there is no island", lb.line)})
+		}
+		if ia == nil || ib == nil {
+			continue
+		}
+		r := ia.RiverWith(ib)
+		if r == nil {
+			vs = append(vs, Violation{Kind: BadBridge, Row: lb.r1, Col: lb.c1, Islands: []*Island{ia, ib}, Message: fmt.Sprintf("line %d: there is no open water straight between this island and (r%d, c%d)", lb.line, lb.r2, lb.c2)})
+			continue
+		}
+		if lb.count < 0 {
+			vs = append(vs, Violation{Kind: BadBridge, Row: lb.r1, Col: lb.c1, Message: fmt.Sprintf("line %d: a bridge count cannot be negative", lb.line)})
+			continue
+		}
+		b.forceBridges(r, lb.count)
+	}
+	return vs
+}
@@ -0,0 +1,68 @@
+package hashi
+
+import "testing"
+
+func TestVerifySolution(t *testing.T) {
+	square := "2.2\n...\n2.2\n"
+	for _, tc := range []struct {
+		puzzle   string
+		solution string
+		//cells that must have a violation; none means a valid solution
+		cells [][2]int
+	}{
+		{square, "2-2\n|.|\n2-2\n", nil},
+		{square, "0 0 0 2\n2 0 2 2\n# the sides\n0 0 2 0 1\n0 2 2 2\n", nil},
+		//a river over its max leaves both of its islands over their counts
+		{square, "0 0 0 2 3\n", [][2]int{{0, 1}, {0, 0}, {0, 2}, {2, 0}}},
+		{square, "2=2\n...\n2=2\n", [][2]int{{0, 0}, {2, 0}}},
+		{square, "2-2\n|.|\n2-3\n", [][2]int{{2, 2}}},
+		{square, "0 0 1 1\n", [][2]int{{1, 1}}},
+		{".1.\n1.1\n.1.\n", "1 0 1 2\n0 1 2 1\n", [][2]int{{1, 1}}},
+	} {
+		puzzle := mustParse(t, tc.puzzle)
+		v, err := VerifySolution(puzzle, tc.solution)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.solution, err)
+		}
+		if v.Solved != (len(tc.cells) == 0) {
+			t.Errorf("%q: solved %v, violations %v", tc.solution, v.Solved, v.Violations)
+		}
+		for _, cell := range tc.cells {
+			found := false
+			for _, vi := range v.Violations {
+				found = found || (vi.Row == cell[0] && vi.Col == cell[1])
+			}
+			if !found {
+				t.Errorf("%q: no violation at (r%d, c%d) in %v", tc.solution, cell[0], cell[1], v.Violations)
+			}
+		}
+	}
+}
+
+func TestVerifySolutionSize(t *testing.T) {
+	puzzle := mustParse(t, "2.2\n...\n2.2\n")
+	if _, err := VerifySolution(puzzle, "2-2\n|.|\n2-2\n|.|\n"); err == nil {
+		t.Errorf("a solution with an extra row was read")
+	}
+}
+
+func TestBridgeList(t *testing.T) {
+	if !IsBridgeList("# r1 c1 r2 c2 count\n0 0 0 2 2\n\n") {
+		t.Errorf("bridge list not recognized")
+	}
+	if IsBridgeList("2=2\n...\n2=2\n") {
+		t.Errorf("drawn grid read as a bridge list")
+	}
+	b := mustParse(t, "2.2\n...\n2.2\n")
+	if err := b.ApplyBridges("0 0 0 2 2\n0 0 2 0 0\n"); err != nil {
+		t.Fatal(err)
+	}
+	if r := b.Grid[0][0].RiverWith(b.Grid[0][2]); r.Bridges != 2 {
+		t.Errorf("river has %d bridges, want 2", r.Bridges)
+	}
+	for _, list := range []string{"0 0 0 2 3\n", "0 0 1 1\n", "0 0 2 2\n", "0 0 0 x\n", "0 0 0\n"} {
+		if err := mustParse(t, "2.2\n...\n2.2\n").ApplyBridges(list); err == nil {
+			t.Errorf("%q: no error", list)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package hashi
+
+import "fmt"
+
+// ViolationKind says which rule of the puzzle a Violation breaks.
+type ViolationKind int
+
+const (
+	//a river holds more bridges than it can
+	RiverOverCapacity ViolationKind = iota
+	//an island has more bridges than its number
+	IslandOverCount
+	//an island has, or can still reach, fewer bridges than its number
+	IslandUnderCount
+	//a group of islands is cut off from the rest
+	IsolatedCluster
+	//two crossing rivers both hold bridges
+	CrossingConflict
+	//a bridge does not run straight between two islands
+	BadBridge
+	//a solution's islands do not match the puzzle's
+	ClueMismatch
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case RiverOverCapacity:
+		return "RiverOverCapacity"
+	case IslandOverCount:
+		return "IslandOverCount"
+	case IslandUnderCount:
+		return "IslandUnderCount"
+	case IsolatedCluster:
+		return "IsolatedCluster"
+	case CrossingConflict:
+		return "CrossingConflict"
+	case BadBridge:
+		return "BadBridge"
+	case ClueMismatch:
+		return "ClueMismatch"
+	}
+	return fmt.Sprintf("ViolationKind(%d)", int(k))
+}
+
+// Violation is one way a board breaks the rules of the puzzle, located at
+// the cell (Row, Col) where it shows up. Islands and Rivers hold the
+// values involved, when there are any.
+type Violation struct {
+	Kind    ViolationKind
+	Row     int
+	Col     int
+	Islands []*Island
+	Rivers  []*River
+	Message string
+}
+
+func (v Violation) Error() string {
+	return v.Message
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("(r%d, c%d): %s", v.Row, v.Col, v.Message)
+}
+
+func islandViolation(kind ViolationKind, i *Island, format string, args ...any) Violation {
+	return Violation{Kind: kind, Row: i.R, Col: i.C, Islands: []*Island{i}, Message: fmt.Sprintf(format, args...)}
+}
+
+func riverViolation(kind ViolationKind, r *River, format string, args ...any) Violation {
+	row, col := riverCell(r)
+	return Violation{Kind: kind, Row: row, Col: col, Islands: r.Islands, Rivers: []*River{r}, Message: fmt.Sprintf(format, args...)}
+}
+
+// Violations returns every way the bridges on b fail to form a solution.
+// It is empty exactly when IsSolved reports true.
+func (b *Board) Violations() []Violation {
+	vs := []Violation{}
+	b.eachViolation(func(v Violation) bool {
+		vs = append(vs, v)
+		return true
+	})
+	return vs
+}
+
+// Mistakes returns every way the current state of b already rules out a
+// solution, however the remaining rivers are filled. It is empty exactly
+// when HasMistakes reports false.
+func (b *Board) Mistakes() []Violation {
+	vs := []Violation{}
+	b.eachMistake(func(v Violation) bool {
+		vs = append(vs, v)
+		return true
+	})
+	return vs
+}
+
+// eachViolation calls visit with each solution violation in turn, stopping
+// early if visit returns false.
+func (b *Board) eachViolation(visit func(Violation) bool) {
+	//1. do all rivers have <= 2 bridges?
+	for _, r := range b.AllRivers {
+		if r.Bridges > r.Max {
+			if !visit(riverViolation(RiverOverCapacity, r, "river %s has %d bridges; max is %d", r, r.Bridges, r.Max)) {
+				return
+			}
+		}
+	}
+
+	//2. does each island have the correct number of bridges?
+	for _, i := range b.AllIslands {
+		kind := IslandUnderCount
+		if i.Bridges > i.Num {
+			kind = IslandOverCount
+		}
+		if i.Bridges != i.Num {
+			if !visit(islandViolation(kind, i, "island %s has %d bridges; target is %d", i, i.Bridges, i.Num)) {
+				return
+			}
+		}
+	}
+
+	//3. are all islands in a single cluster?
+	if len(b.Clusters) > 1 {
+		for _, c := range b.Clusters {
+			if !visit(clusterViolation(c, "cluster %s is not connected to the other %d islands", c, len(b.AllIslands)-c.Size())) {
+				return
+			}
+		}
+	}
+
+	//4. are there clashing bridges (i.e., two crossing rivers each with >= 1 bridge)?
+	b.eachCrossingConflict(visit)
+}
+
+// eachMistake is eachViolation for HasMistakes.
+func (b *Board) eachMistake(visit func(Violation) bool) {
+	//1. do any rivers have too many bridges?
+	for _, r := range b.AllRivers {
+		for _, i := range r.Islands {
+			if r.Bridges > i.Num {
+				if !visit(riverViolation(RiverOverCapacity, r, "river %s has %d bridges; island %s needs %d", r, r.Bridges, i, i.Num)) {
+					return
+				}
+				break
+			}
+		}
+	}
+
+	//2. do any islands have too many bridges, or too few available?
+	for _, i := range b.AllIslands {
+		if i.Bridges > i.Num {
+			if !visit(islandViolation(IslandOverCount, i, "island %s has %d bridges; target is %d", i, i.Bridges, i.Num)) {
+				return
+			}
+		} else if i.Available < i.NumNeeded() {
+			if !visit(islandViolation(IslandUnderCount, i, "island %s needs %d bridges, but only %d are available", i, i.NumNeeded(), i.Available)) {
+				return
+			}
+		}
+	}
+
+	//3. is there an incomplete cluster with no edges?
+	if len(b.Clusters) > 1 {
+		for _, c := range b.Clusters {
+			if c.NumEdges() == 0 {
+				if !visit(clusterViolation(c, "cluster %s has no edges and does not contain all islands", c)) {
+					return
+				}
+			}
+		}
+	}
+
+	//4. are there clashing bridges (i.e., two crossing rivers each with >= 1 bridge)?
+	b.eachCrossingConflict(visit)
+}
+
+// eachCrossingConflict visits each pair of crossing rivers that both hold
+// bridges once, at the cell where they cross.
+func (b *Board) eachCrossingConflict(visit func(Violation) bool) {
+	for _, r := range b.AllRivers {
+		if r.Bridges == 0 || r.Islands[0].R != r.Islands[1].R {
+			continue
+		}
+		for _, cross := range r.Crossings {
+			if cross.Bridges == 0 {
+				continue
+			}
+			v := Violation{
+				Kind:    CrossingConflict,
+				Row:     r.Islands[0].R,
+				Col:     cross.Islands[0].C,
+				Islands: []*Island{r.Islands[0], r.Islands[1], cross.Islands[0], cross.Islands[1]},
+				Rivers:  []*River{r, cross},
+				Message: fmt.Sprintf("bridges %s and %s cross, but both have bridges (%d and %d)", r, cross, r.Bridges, cross.Bridges),
+			}
+			if !visit(v) {
+				return
+			}
+		}
+	}
+}
+
+// clusterViolation reports c as isolated at its first island.
+func clusterViolation(c *Cluster, format string, args ...any) Violation {
+	islands := c.Islands()
+	first := islands[0]
+	for _, i := range islands {
+		if i.Index < first.Index {
+			first = i
+		}
+	}
+	return Violation{Kind: IsolatedCluster, Row: first.R, Col: first.C, Islands: islands, Message: fmt.Sprintf(format, args...)}
+}
+
+// riverCell returns the first cell r crosses, or its first island's cell if
+// its islands are next to each other.
+func riverCell(r *River) (int, int) {
+	ia, ib := r.Islands[0], r.Islands[1]
+	if ia.R == ib.R && min(ia.C, ib.C)+1 < max(ia.C, ib.C) {
+		return ia.R, min(ia.C, ib.C) + 1
+	}
+	if ia.C == ib.C && min(ia.R, ib.R)+1 < max(ia.R, ib.R) {
+		return min(ia.R, ib.R) + 1, ia.C
+	}
+	return ia.R, ia.C
+}
@@ -0,0 +1,83 @@
+package hashi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// withBridges parses puzzle and forces the bridges in list, one
+// "r1 c1 r2 c2 count" per line, onto it whether or not the rules allow
+// them.
+func withBridges(t *testing.T, puzzle string, list string) *Board {
+	t.Helper()
+	b := mustParse(t, puzzle)
+	for _, line := range strings.Split(strings.TrimSpace(list), "\n") {
+		var r1, c1, r2, c2, ct int
+		if _, err := fmt.Sscan(line, &r1, &c1, &r2, &c2, &ct); err != nil {
+			t.Fatalf("%q: %v", line, err)
+		}
+		b.forceBridges(b.Grid[r1][c1].RiverWith(b.Grid[r2][c2]), ct)
+	}
+	return b
+}
+
+func TestViolationKinds(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		b       *Board
+		kind    ViolationKind
+		cell    [2]int
+		islands [][2]int
+		//each river by its islands' cells
+		rivers [][2][2]int
+	}{
+		{"over capacity", withBridges(t, "3.3\n", "0 0 0 2 3\n"), RiverOverCapacity, [2]int{0, 1},
+			[][2]int{{0, 0}, {0, 2}}, [][2][2]int{{{0, 0}, {0, 2}}}},
+		{"over count", withBridges(t, "1.2\n", "0 0 0 2 2\n"), IslandOverCount, [2]int{0, 0},
+			[][2]int{{0, 0}}, nil},
+		{"under count", withBridges(t, "2.1\n", "0 0 0 2 1\n"), IslandUnderCount, [2]int{0, 0},
+			[][2]int{{0, 0}}, nil},
+		{"isolated", withBridges(t, "1.1\n...\n1.1\n", "0 0 0 2 1\n2 0 2 2 1\n"), IsolatedCluster, [2]int{2, 0},
+			[][2]int{{2, 0}, {2, 2}}, nil},
+		{"crossing", withBridges(t, ".1.\n1.1\n.1.\n", "1 0 1 2 1\n0 1 2 1 1\n"), CrossingConflict, [2]int{1, 1},
+			[][2]int{{1, 0}, {1, 2}, {0, 1}, {2, 1}}, [][2][2]int{{{1, 0}, {1, 2}}, {{0, 1}, {2, 1}}}},
+	} {
+		var found *Violation
+		vs := tc.b.Violations()
+		for idx := range vs {
+			if vs[idx].Kind == tc.kind && vs[idx].Row == tc.cell[0] && vs[idx].Col == tc.cell[1] {
+				found = &vs[idx]
+			}
+		}
+		if found == nil {
+			t.Errorf("%s: no %s at (r%d, c%d) in %v", tc.name, tc.kind, tc.cell[0], tc.cell[1], vs)
+			continue
+		}
+		islands := map[*Island]bool{}
+		for _, i := range found.Islands {
+			islands[i] = true
+		}
+		if len(islands) != len(tc.islands) || len(found.Islands) != len(tc.islands) {
+			t.Errorf("%s: islands %v, want %v", tc.name, found.Islands, tc.islands)
+		}
+		for _, cell := range tc.islands {
+			if !islands[tc.b.Grid[cell[0]][cell[1]]] {
+				t.Errorf("%s: island at %v missing from %v", tc.name, cell, found.Islands)
+			}
+		}
+		if len(found.Rivers) != len(tc.rivers) {
+			t.Errorf("%s: rivers %v, want %v", tc.name, found.Rivers, tc.rivers)
+		}
+		for _, ends := range tc.rivers {
+			r := tc.b.Grid[ends[0][0]][ends[0][1]].RiverWith(tc.b.Grid[ends[1][0]][ends[1][1]])
+			has := false
+			for _, other := range found.Rivers {
+				has = has || other == r
+			}
+			if !has {
+				t.Errorf("%s: river %s missing from %v", tc.name, r, found.Rivers)
+			}
+		}
+	}
+}